@@ -1,6 +1,9 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // ActionsService handles communication with the servers related
 // methods of the Scaleway API.
@@ -20,22 +23,13 @@ type actionResponse struct {
 	Task *Task `json:"task"`
 }
 
-// Task represents the return for an action.
-type Task struct {
-	Description string `json:"description,omitempty"`
-	HrefFrom    string `json:"href_from,omitempty"`
-	ID          string `json:"id,omitempty"`
-	Progress    string `json:"progress,omitempty"`
-	Status      string `json:"status,omitempty"`
-}
-
 // actionResponse represents a Scaleway actions list response.
 type actionListResponse struct {
 	Actions []string `json:"actions,omitempty"`
 }
 
 // Exec executes action for a specific server.
-func (s *ActionsService) Exec(id string, ar *ActionRequest) (*Task, *Response, error) {
+func (s *ActionsService) Exec(ctx context.Context, id string, ar *ActionRequest) (*Task, *Response, error) {
 	u := fmt.Sprintf("/servers/%s/action", id)
 	req, err := s.client.NewRequestCompute("POST", u, ar)
 	if err != nil {
@@ -43,7 +37,7 @@ func (s *ActionsService) Exec(id string, ar *ActionRequest) (*Task, *Response, e
 	}
 
 	action := new(actionResponse)
-	resp, err := s.client.Do(req, action)
+	resp, err := s.client.Do(ctx, req, action)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -51,11 +45,11 @@ func (s *ActionsService) Exec(id string, ar *ActionRequest) (*Task, *Response, e
 }
 
 // List returns a list of all actions associate to your account.
-func (s *ActionsService) List(id string) ([]string, *Response, error) {
-	return s.listActions(id)
+func (s *ActionsService) List(ctx context.Context, id string) ([]string, *Response, error) {
+	return s.listActions(ctx, id)
 }
 
-func (s *ActionsService) listActions(id string) ([]string, *Response, error) {
+func (s *ActionsService) listActions(ctx context.Context, id string) ([]string, *Response, error) {
 	u := fmt.Sprintf("/servers/%s/action", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
@@ -63,7 +57,7 @@ func (s *ActionsService) listActions(id string) ([]string, *Response, error) {
 	}
 
 	actions := new(actionListResponse)
-	resp, err := s.client.Do(req, actions)
+	resp, err := s.client.Do(ctx, req, actions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -37,7 +38,7 @@ func TestActionsService_Exec(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	task, _, err := client.Actions.Exec(serverID, inBody)
+	task, _, err := client.Actions.Exec(context.Background(), serverID, inBody)
 	if err != nil {
 		t.Errorf("Actions.Exec returned error: %v", err)
 	}
@@ -71,7 +72,7 @@ func TestActionsService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	actions, _, err := client.Actions.List(serverID)
+	actions, _, err := client.Actions.List(context.Background(), serverID)
 	if err != nil {
 		t.Errorf("Actions.List returned error: %v", err)
 	}
@@ -0,0 +1,100 @@
+package scaleway
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const headerAuthToken = "X-Auth-Token"
+
+// An Authenticator authenticates the requests sent by a Client.
+type Authenticator interface {
+	// Authenticate adds credentials to req, typically as headers.
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// A Reauthenticator is an Authenticator whose credentials may be rejected by
+// the API, e.g. expiring tokens. When a request fails with a 401
+// Unauthorized, the Client calls Invalidate with the rejected request, then
+// authenticates and sends it again once.
+type Reauthenticator interface {
+	Authenticator
+	// Invalidate discards the credentials used to authenticate req.
+	Invalidate(req *http.Request)
+}
+
+// StaticToken authenticates requests with a fixed auth-token.
+type StaticToken string
+
+// Authenticate sets the X-Auth-Token header of req to the token.
+func (t StaticToken) Authenticate(ctx context.Context, req *http.Request) error {
+	req.Header.Set(headerAuthToken, string(t))
+	return nil
+}
+
+// APIKey authenticates requests with an IAM API key.
+type APIKey struct {
+	// AccessKey identifies the key, e.g. "SCWXXXXXXXXXXXXXXXXX". It is not
+	// sent to the API.
+	AccessKey string
+	// SecretKey authenticates requests.
+	SecretKey string
+}
+
+// Authenticate sets the X-Auth-Token header of req to the secret key.
+func (k *APIKey) Authenticate(ctx context.Context, req *http.Request) error {
+	req.Header.Set(headerAuthToken, k.SecretKey)
+	return nil
+}
+
+// authenticator returns the Authenticator of the client, falling back to a
+// StaticToken if AuthToken is set.
+func (c *Client) authenticator() Authenticator {
+	if c.Authenticator != nil {
+		return c.Authenticator
+	}
+	if c.AuthToken != "" {
+		return StaticToken(c.AuthToken)
+	}
+	return nil
+}
+
+// sendAuthenticated sends req authenticated by the Authenticator of the
+// client. If the API rejects the credentials of a Reauthenticator, req is
+// authenticated and sent again.
+func (c *Client) sendAuthenticated(ctx context.Context, req *http.Request) (*http.Response, error) {
+	auth := c.authenticator()
+	if auth == nil {
+		return c.send(ctx, req)
+	}
+
+	if err := auth.Authenticate(ctx, req); err != nil {
+		return nil, err
+	}
+	resp, err := c.send(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	reauth, ok := auth.(Reauthenticator)
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, err
+	}
+
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	reauth.Invalidate(req)
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	if err := auth.Authenticate(ctx, req); err != nil {
+		return nil, err
+	}
+	return c.send(ctx, req)
+}
@@ -0,0 +1,95 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Do_authToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Auth-Token"), "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"; got != want {
+			t.Errorf("X-Auth-Token is %v, want %v", got, want)
+		}
+	})
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+}
+
+func TestClient_Do_apiKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+	client.Authenticator = &APIKey{
+		AccessKey: "SCWXXXXXXXXXXXXXXXXX",
+		SecretKey: "a4f3d1b6-2a5e-4d3c-9f0e-7b8c6d5e4f3a",
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Auth-Token"), "a4f3d1b6-2a5e-4d3c-9f0e-7b8c6d5e4f3a"; got != want {
+			t.Errorf("X-Auth-Token is %v, want %v", got, want)
+		}
+	})
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+}
+
+// authenticatorFunc authenticates requests by calling itself.
+type authenticatorFunc func(ctx context.Context, req *http.Request) error
+
+func (f authenticatorFunc) Authenticate(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+func TestClient_Do_authenticateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	boom := errors.New("boom")
+	client.Authenticator = authenticatorFunc(func(ctx context.Context, req *http.Request) error {
+		return boom
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request sent despite the authentication error")
+	})
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != boom {
+		t.Errorf("Do returned %v, want %v", err, boom)
+	}
+}
+
+func TestClient_Do_unauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if !IsUnauthorized(err) {
+		t.Errorf("Do returned %v, want a 401 error", err)
+	}
+	if calls != 1 {
+		t.Errorf("Do sent %d requests with a StaticToken, want 1", calls)
+	}
+}
@@ -0,0 +1,152 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+)
+
+// BootscriptsService handles communication with the bootscripts related
+// methods of the Scaleway API.
+//
+// Scaleway API docs: https://developer.scaleway.com/#bootscripts
+type BootscriptsService struct {
+	client *Client
+}
+
+// Bootscript represents a Scaleway bootscript, the kernel and initrd used
+// to boot a server.
+type Bootscript struct {
+	ID           string `json:"id,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Arch         string `json:"architecture,omitempty"`
+	Kernel       string `json:"kernel,omitempty"`
+	Initrd       string `json:"initrd,omitempty"`
+	Bootcmdargs  string `json:"bootcmdargs,omitempty"`
+	DTB          string `json:"dtb,omitempty"`
+	Default      bool   `json:"default,omitempty"`
+	Public       bool   `json:"public,omitempty"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// BootscriptListOptions specifies the optional parameters to the
+// BootscriptsService.List method. Filters are sent as query parameters and
+// also applied to the returned bootscripts, in case the API ignores them.
+type BootscriptListOptions struct {
+	// Arch filters bootscripts of the given architecture, e.g. "arm".
+	Arch string `url:"arch,omitempty"`
+	// Default filters default or non default bootscripts when set.
+	Default *bool `url:"default,omitempty"`
+
+	ListOptions
+}
+
+// bootscriptResponse represents a Scaleway bootscript response.
+type bootscriptResponse struct {
+	Bootscript *Bootscript `json:"bootscript"`
+}
+
+// bootscriptListResponse represents a Scaleway bootscripts list response.
+type bootscriptListResponse struct {
+	Bootscripts []*Bootscript `json:"bootscripts"`
+}
+
+// List returns a list of all bootscripts.
+func (s *BootscriptsService) List(ctx context.Context, opt *BootscriptListOptions) ([]*Bootscript, *Response, error) {
+	return s.listBootscripts(ctx, opt)
+}
+
+func (s *BootscriptsService) listBootscripts(ctx context.Context, opt *BootscriptListOptions) ([]*Bootscript, *Response, error) {
+	u, err := addOptions("/bootscripts", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bootscripts := new(bootscriptListResponse)
+	resp, err := s.client.Do(ctx, req, bootscripts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opt.filter(bootscripts.Bootscripts), resp, nil
+}
+
+// ListPages calls fn for every page of bootscripts, starting from the page
+// set in opt, until there are no more pages or fn returns false. Each page
+// is only fetched once the previous one has been handled.
+func (s *BootscriptsService) ListPages(ctx context.Context, opt *BootscriptListOptions, fn func([]*Bootscript) bool) error {
+	o := BootscriptListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		bootscripts, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(bootscripts), nil
+	})
+}
+
+// ListAll returns the bootscripts of every page, starting from the page set
+// in opt.
+func (s *BootscriptsService) ListAll(ctx context.Context, opt *BootscriptListOptions) ([]*Bootscript, error) {
+	var all []*Bootscript
+	err := s.ListPages(ctx, opt, func(bootscripts []*Bootscript) bool {
+		all = append(all, bootscripts...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Get returns info for a specific bootscript.
+func (s *BootscriptsService) Get(ctx context.Context, id string) (*Bootscript, *Response, error) {
+	u := fmt.Sprintf("/bootscripts/%s", id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bootscript := new(bootscriptResponse)
+	resp, err := s.client.Do(ctx, req, bootscript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bootscript.Bootscript, resp, nil
+}
+
+// Default returns the default bootscript for the architecture arch, such as
+// the Arch of the image a server is created from.
+func (s *BootscriptsService) Default(ctx context.Context, arch string) (*Bootscript, error) {
+	bootscripts, err := s.ListAll(ctx, &BootscriptListOptions{Arch: arch, Default: Bool(true)})
+	if err != nil {
+		return nil, err
+	}
+	if len(bootscripts) == 0 {
+		return nil, fmt.Errorf("no default bootscript for architecture %q", arch)
+	}
+	return bootscripts[0], nil
+}
+
+// filter returns the bootscripts matching the filters of opt.
+func (opt *BootscriptListOptions) filter(bootscripts []*Bootscript) []*Bootscript {
+	if opt == nil {
+		return bootscripts
+	}
+	filtered := bootscripts[:0]
+	for _, b := range bootscripts {
+		if !matchString(b.Arch, opt.Arch) ||
+			(opt.Default != nil && b.Default != *opt.Default) {
+			continue
+		}
+		filtered = append(filtered, b)
+	}
+	return filtered
+}
@@ -0,0 +1,99 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testBootscript = &Bootscript{
+	ID:           "599b736c-48b5-4530-9764-f04d06ecadc7",
+	Title:        "armv7l 4.5.7 std #9 (stable)",
+	Arch:         "arm",
+	Kernel:       "http://169.254.42.24/kernel/armv7l-4.5.7-std-9",
+	Initrd:       "http://169.254.42.24/initrd/initrd-Linux-armv7l-v3.11.1.gz",
+	Bootcmdargs:  "LINUX_COMMON scaleway boot=local",
+	DTB:          "dtb/c1-armv7l-4.5.7-std-9",
+	Default:      true,
+	Public:       true,
+	Organization: "11111111-1111-4111-8111-111111111111",
+}
+
+func TestBootscriptsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "bootscripts_list.json"))
+
+	mux.HandleFunc("/bootscripts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.RawQuery, "arch=arm"; got != want {
+			t.Errorf("Request query = %v, want %v", got, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	bootscripts, _, err := client.Bootscripts.List(context.Background(), &BootscriptListOptions{Arch: "arm"})
+	if err != nil {
+		t.Errorf("Bootscripts.List returned error: %v", err)
+	}
+	if want := []*Bootscript{testBootscript}; !reflect.DeepEqual(bootscripts, want) {
+		t.Errorf("Bootscripts.List returned %+v\n, want %+v", bootscripts, want)
+	}
+}
+
+func TestBootscriptsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "bootscripts_get.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/bootscripts/%s", testBootscript.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	bootscript, _, err := client.Bootscripts.Get(context.Background(), testBootscript.ID)
+	if err != nil {
+		t.Errorf("Bootscripts.Get returned error: %v", err)
+	}
+	if !reflect.DeepEqual(bootscript, testBootscript) {
+		t.Errorf("Bootscripts.Get returned %+v\n, want %+v", bootscript, testBootscript)
+	}
+}
+
+func TestBootscriptsService_Default(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "bootscripts_list.json"))
+
+	mux.HandleFunc("/bootscripts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	bootscript, err := client.Bootscripts.Default(context.Background(), "x86_64")
+	if err != nil {
+		t.Errorf("Bootscripts.Default returned error: %v", err)
+	}
+	if got, want := bootscript.ID, "e8f4e9a1-0d5b-4c49-a58b-1b4c2f8b1f4f"; got != want {
+		t.Errorf("Bootscripts.Default returned %v, want %v", got, want)
+	}
+
+	if _, err := client.Bootscripts.Default(context.Background(), "arm64"); err == nil {
+		t.Error("Bootscripts.Default returned no error for an unknown architecture")
+	}
+}
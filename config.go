@@ -0,0 +1,261 @@
+package scaleway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Environment variables read by NewClientFromEnv and NewClientFromConfig.
+const (
+	EnvToken        = "SCW_TOKEN"
+	EnvOrganization = "SCW_ORGANIZATION"
+	EnvRegion       = "SCW_REGION"
+	EnvConfigPath   = "SCW_CONFIG_PATH"
+	EnvProfile      = "SCW_PROFILE"
+)
+
+// defaultConfigFile is the name of the config file in the home directory,
+// shared with the scw command line tool.
+const defaultConfigFile = ".scwrc"
+
+// Config holds the settings used to build a Client.
+//
+// Config files are JSON. They hold either a single set of settings, as the
+// ~/.scwrc file written by the scw command line tool:
+//
+//	{"token": "...", "organization": "...", "region": "par1"}
+//
+// or named profiles:
+//
+//	{
+//	  "default_profile": "prod",
+//	  "profiles": {
+//	    "prod": {"token": "...", "organization": "...", "region": "par1"},
+//	    "test": {"token": "...", "organization": "...", "region": "ams1"}
+//	  }
+//	}
+type Config struct {
+	Token        string `json:"token,omitempty"`
+	Organization string `json:"organization,omitempty"`
+	Region       Region `json:"region,omitempty"`
+	// ComputeURL overrides the compute endpoint of Region. It is ignored
+	// if Region is set by a layer of higher precedence, e.g. SCW_REGION
+	// overrides the api_endpoint of a config file.
+	ComputeURL string `json:"api_endpoint,omitempty"`
+	AccountURL string `json:"account_endpoint,omitempty"`
+
+	// Path of the config file. Defaults to $SCW_CONFIG_PATH, then to
+	// ~/.scwrc, which may not exist.
+	Path string `json:"-"`
+	// Profile of the config file to use. Defaults to $SCW_PROFILE, then to
+	// the default_profile of the file, then to "default".
+	Profile string `json:"-"`
+}
+
+// configFile is the content of a config file.
+type configFile struct {
+	Config
+	DefaultProfile string             `json:"default_profile,omitempty"`
+	Profiles       map[string]*Config `json:"profiles,omitempty"`
+}
+
+// ConfigError reports an invalid or missing setting, along with the source
+// it was read from.
+type ConfigError struct {
+	// Source of the setting, e.g. "environment variable SCW_REGION" or
+	// "/home/me/.scwrc". Empty when the setting is missing.
+	Source string
+	// Field is the name of the setting, e.g. "region".
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("scaleway: %s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("scaleway: invalid %s from %s: %v", e.Field, e.Source, e.Err)
+}
+
+// configLayer is a set of settings read from a single source.
+type configLayer struct {
+	cfg    Config
+	source func(field string) string
+}
+
+// NewClientFromEnv returns a new Client configured from the SCW_TOKEN,
+// SCW_ORGANIZATION and SCW_REGION environment variables. If a nil
+// httpClient is provided, http.DefaultClient will be used.
+func NewClientFromEnv(httpClient *http.Client) (*Client, error) {
+	return newClientFromLayers(httpClient, []configLayer{envLayer()})
+}
+
+// NewClientFromConfig returns a new Client configured from cfg, the
+// environment and a config file, in that order of precedence: a setting of
+// cfg overrides the matching environment variable, which overrides the
+// config file. cfg may be nil. If a nil httpClient is provided,
+// http.DefaultClient will be used.
+func NewClientFromConfig(httpClient *http.Client, cfg *Config) (*Client, error) {
+	explicit := Config{}
+	if cfg != nil {
+		explicit = *cfg
+	}
+
+	layers := []configLayer{
+		{cfg: explicit, source: func(string) string { return "config" }},
+		envLayer(),
+	}
+	file, err := loadConfigFile(firstNonEmpty(explicit.Path, os.Getenv(EnvConfigPath)),
+		firstNonEmpty(explicit.Profile, os.Getenv(EnvProfile)))
+	if err != nil {
+		return nil, err
+	}
+	if file != nil {
+		layers = append(layers, *file)
+	}
+	return newClientFromLayers(httpClient, layers)
+}
+
+// envLayer returns the settings read from the environment.
+func envLayer() configLayer {
+	vars := map[string]string{
+		"token":        EnvToken,
+		"organization": EnvOrganization,
+		"region":       EnvRegion,
+	}
+	return configLayer{
+		cfg: Config{
+			Token:        os.Getenv(EnvToken),
+			Organization: os.Getenv(EnvOrganization),
+			Region:       Region(os.Getenv(EnvRegion)),
+		},
+		source: func(field string) string { return "environment variable " + vars[field] },
+	}
+}
+
+// loadConfigFile reads the profile of the config file at path. The default
+// config file is ignored if it doesn't exist.
+func loadConfigFile(path, profile string) (*configLayer, error) {
+	explicit := path != ""
+	if !explicit {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(home, defaultConfigFile)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ConfigError{Source: path, Field: "file", Err: err}
+	}
+
+	var f configFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, &ConfigError{Source: path, Field: "file", Err: err}
+	}
+
+	source := path
+	cfg := f.Config
+	if f.Profiles != nil {
+		profile = firstNonEmpty(profile, f.DefaultProfile, "default")
+		p, ok := f.Profiles[profile]
+		if !ok || p == nil {
+			return nil, &ConfigError{Source: path, Field: "profile", Err: fmt.Errorf("no profile %q", profile)}
+		}
+		cfg = *p
+		source = fmt.Sprintf("%s (profile %q)", path, profile)
+	} else if profile != "" && profile != "default" {
+		return nil, &ConfigError{Source: path, Field: "profile", Err: fmt.Errorf("no profile %q", profile)}
+	}
+
+	return &configLayer{cfg: cfg, source: func(string) string { return source }}, nil
+}
+
+// newClientFromLayers returns a new Client configured from the first layer
+// setting each field.
+func newClientFromLayers(httpClient *http.Client, layers []configLayer) (*Client, error) {
+	var cfg Config
+	sources := make(map[string]string)
+	layerOf := make(map[string]int)
+	for i, l := range layers {
+		set := func(field string, dst *string, v string) {
+			if *dst == "" && v != "" {
+				*dst = v
+				sources[field] = l.source(field)
+				layerOf[field] = i
+			}
+		}
+		region := string(cfg.Region)
+		set("token", &cfg.Token, l.cfg.Token)
+		set("organization", &cfg.Organization, l.cfg.Organization)
+		set("region", &region, string(l.cfg.Region))
+		set("api_endpoint", &cfg.ComputeURL, l.cfg.ComputeURL)
+		set("account_endpoint", &cfg.AccountURL, l.cfg.AccountURL)
+		cfg.Region = Region(region)
+	}
+
+	if cfg.Token == "" {
+		return nil, &ConfigError{Field: "token", Err: errors.New("missing, set " + EnvToken + " or a config file")}
+	}
+
+	c := NewClient(httpClient)
+	c.AuthToken = cfg.Token
+	c.Organization = cfg.Organization
+	if cfg.Region != "" {
+		if err := c.SetRegion(cfg.Region); err != nil {
+			return nil, &ConfigError{Source: sources["region"], Field: "region", Err: err}
+		}
+	}
+	// The compute endpoint and the region select the same API, so an
+	// endpoint only applies if set with or above the region.
+	if cfg.ComputeURL != "" && (cfg.Region == "" || layerOf["api_endpoint"] <= layerOf["region"]) {
+		u, err := parseBaseURL(cfg.ComputeURL)
+		if err != nil {
+			return nil, &ConfigError{Source: sources["api_endpoint"], Field: "api_endpoint", Err: err}
+		}
+		c.ComputeBaseURL = u
+	}
+	if cfg.AccountURL != "" {
+		u, err := parseBaseURL(cfg.AccountURL)
+		if err != nil {
+			return nil, &ConfigError{Source: sources["account_endpoint"], Field: "account_endpoint", Err: err}
+		}
+		c.AccountBaseURL = u
+	}
+	return c, nil
+}
+
+// parseBaseURL parses an absolute base URL, adding the trailing slash
+// expected by ResolveReference if needed.
+func parseBaseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", s)
+	}
+	if u.Path == "" || u.Path[len(u.Path)-1] != '/' {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// firstNonEmpty returns the first non-empty string of values.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,178 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupConfigEnv clears the configuration environment variables and points
+// the home directory to an empty temporary directory.
+func setupConfigEnv(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, v := range []string{EnvToken, EnvOrganization, EnvRegion, EnvConfigPath, EnvProfile} {
+		t.Setenv(v, "")
+	}
+	return home
+}
+
+// writeConfig writes a config file in dir and returns its path.
+func writeConfig(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	setupConfigEnv(t)
+	t.Setenv(EnvToken, "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7")
+	t.Setenv(EnvOrganization, "000a115d-2852-4b0a-9ce8-47f1134ba95a")
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvRegion, "ams1")
+
+	c, err := NewClientFromEnv(nil)
+	if err != nil {
+		t.Fatalf("NewClientFromEnv returned error: %v", err)
+	}
+
+	if got, want := c.AuthToken, "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"; got != want {
+		t.Errorf("NewClientFromEnv AuthToken is %v, want %v", got, want)
+	}
+	if got, want := c.Organization, "000a115d-2852-4b0a-9ce8-47f1134ba95a"; got != want {
+		t.Errorf("NewClientFromEnv Organization is %v, want %v", got, want)
+	}
+	if got, want := c.ComputeBaseURL.String(), "https://cp-ams1.scaleway.com/"; got != want {
+		t.Errorf("NewClientFromEnv ComputeBaseURL is %v, want %v", got, want)
+	}
+}
+
+func TestNewClientFromEnv_errors(t *testing.T) {
+	setupConfigEnv(t)
+
+	_, err := NewClientFromEnv(nil)
+	if e, ok := err.(*ConfigError); !ok || e.Field != "token" {
+		t.Errorf("NewClientFromEnv without token returned %v, want a token *ConfigError", err)
+	}
+
+	t.Setenv(EnvToken, "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7")
+	t.Setenv(EnvRegion, "xxx1")
+	_, err = NewClientFromEnv(nil)
+	e, ok := err.(*ConfigError)
+	if !ok || e.Field != "region" || e.Source != "environment variable SCW_REGION" {
+		t.Fatalf("NewClientFromEnv with invalid region returned %v, want a region *ConfigError", err)
+	}
+	if !strings.Contains(e.Error(), EnvRegion) {
+		t.Errorf("ConfigError %q doesn't name %v", e.Error(), EnvRegion)
+	}
+}
+
+func TestNewClientFromConfig_legacyFile(t *testing.T) {
+	home := setupConfigEnv(t)
+	writeConfig(t, home, ".scwrc", `{
+		"api_endpoint": "https://cp-par1.scaleway.com",
+		"account_endpoint": "https://account.scaleway.com",
+		"organization": "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		"token": "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7",
+		"version": "v1.11"
+	}`)
+
+	c, err := NewClientFromConfig(nil, nil)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig returned error: %v", err)
+	}
+
+	if got, want := c.AuthToken, "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"; got != want {
+		t.Errorf("NewClientFromConfig AuthToken is %v, want %v", got, want)
+	}
+	if got, want := c.ComputeBaseURL.String(), "https://cp-par1.scaleway.com/"; got != want {
+		t.Errorf("NewClientFromConfig ComputeBaseURL is %v, want %v", got, want)
+	}
+}
+
+func TestNewClientFromConfig_precedence(t *testing.T) {
+	home := setupConfigEnv(t)
+	path := writeConfig(t, home, "config.json", `{
+		"default_profile": "prod",
+		"profiles": {
+			"prod": {"token": "file-token", "organization": "file-org", "region": "par1"},
+			"test": {"token": "test-token", "region": "ams1"}
+		}
+	}`)
+	t.Setenv(EnvConfigPath, path)
+	t.Setenv(EnvOrganization, "env-org")
+
+	c, err := NewClientFromConfig(nil, &Config{Region: RegionAms1})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig returned error: %v", err)
+	}
+
+	if got, want := c.AuthToken, "file-token"; got != want {
+		t.Errorf("NewClientFromConfig AuthToken is %v, want %v", got, want)
+	}
+	if got, want := c.Organization, "env-org"; got != want {
+		t.Errorf("NewClientFromConfig Organization is %v, want %v", got, want)
+	}
+	if got, want := c.Region, RegionAms1; got != want {
+		t.Errorf("NewClientFromConfig Region is %v, want %v", got, want)
+	}
+
+	t.Setenv(EnvProfile, "test")
+	c, err = NewClientFromConfig(nil, nil)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig returned error: %v", err)
+	}
+	if got, want := c.AuthToken, "test-token"; got != want {
+		t.Errorf("NewClientFromConfig with profile AuthToken is %v, want %v", got, want)
+	}
+
+	// The region of the environment overrides the endpoint of the file.
+	path = writeConfig(t, home, "endpoint.json", `{"token": "file-token", "api_endpoint": "https://cp-par1.scaleway.com/"}`)
+	t.Setenv(EnvConfigPath, path)
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvRegion, "ams1")
+	c, err = NewClientFromConfig(nil, nil)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig returned error: %v", err)
+	}
+	if got, want := c.Region, RegionAms1; got != want {
+		t.Errorf("NewClientFromConfig with endpoint Region is %v, want %v", got, want)
+	}
+	if got, want := c.ComputeBaseURL.String(), "https://cp-ams1.scaleway.com/"; got != want {
+		t.Errorf("NewClientFromConfig with endpoint ComputeBaseURL is %v, want %v", got, want)
+	}
+}
+
+func TestNewClientFromConfig_errors(t *testing.T) {
+	home := setupConfigEnv(t)
+	path := writeConfig(t, home, "config.json", `{
+		"profiles": {
+			"default": {"token": "file-token", "api_endpoint": "cp-par1.scaleway.com"}
+		}
+	}`)
+
+	tests := []struct {
+		cfg   *Config
+		field string
+	}{
+		{&Config{Path: filepath.Join(home, "missing.json")}, "file"},
+		{&Config{Path: writeConfig(t, home, "invalid.json", `{`)}, "file"},
+		{&Config{Path: path, Profile: "prod"}, "profile"},
+		{&Config{Path: path}, "api_endpoint"},
+	}
+
+	for _, tt := range tests {
+		_, err := NewClientFromConfig(nil, tt.cfg)
+		e, ok := err.(*ConfigError)
+		if !ok || e.Field != tt.field {
+			t.Errorf("NewClientFromConfig(%+v) returned %v, want a %s *ConfigError", tt.cfg, err, tt.field)
+			continue
+		}
+		if !strings.HasPrefix(e.Source, tt.cfg.Path) {
+			t.Errorf("NewClientFromConfig(%+v) returned source %q, want %q", tt.cfg, e.Source, tt.cfg.Path)
+		}
+	}
+}
@@ -1,9 +1,23 @@
 package scaleway
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 // Credentials represents a Scaleway login composed by email and password.
 type Credentials struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// TwoFactorToken is the two-factor authentication code of accounts
+	// with 2FA enabled.
+	TwoFactorToken string `json:"2FA_token,omitempty"`
+	// TwoFactorPrompt, if set, is called by TokensService.Create to ask for
+	// a two-factor authentication code when the API requires one, or
+	// rejects the one sent.
+	TwoFactorPrompt func(ctx context.Context) (string, error) `json:"-"`
 }
 
 // NewCredentials returns a new Scaleway Credential need for generating
@@ -14,3 +28,39 @@ func NewCredentials(email string, password string) *Credentials {
 		Password: password,
 	}
 }
+
+// TwoFactorError occurs when creating a token for an account with two-factor
+// authentication enabled, without a valid code.
+type TwoFactorError struct {
+	*ErrorResponse
+	// Invalid reports whether a code was sent and rejected, rather than
+	// missing.
+	Invalid bool
+}
+
+func (e *TwoFactorError) Error() string {
+	if e.Invalid {
+		return fmt.Sprintf("invalid two-factor authentication code: %v", e.ErrorResponse)
+	}
+	return fmt.Sprintf("two-factor authentication code required: %v", e.ErrorResponse)
+}
+
+// IsTwoFactorRequired reports whether err is caused by a missing or invalid
+// two-factor authentication code.
+func IsTwoFactorRequired(err error) bool {
+	_, ok := err.(*TwoFactorError)
+	return ok
+}
+
+// isTwoFactorError reports whether e is the error returned by the API when
+// the two-factor authentication code is missing or invalid.
+func isTwoFactorError(e *ErrorResponse) bool {
+	if c := e.Response.StatusCode; c != http.StatusUnauthorized && c != http.StatusForbidden {
+		return false
+	}
+	if _, ok := e.Fields["2FA_token"]; ok {
+		return true
+	}
+	s := strings.ToLower(e.Type + " " + e.Message)
+	return strings.Contains(s, "2fa") || strings.Contains(s, "two-factor")
+}
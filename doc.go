@@ -11,11 +11,14 @@ access differents parts of the Scaleway API. For example:
     // Create a client
     client := scaleway.NewClient(nil)
 
+    // Every API call takes a context.Context
+    ctx := context.Background()
+
     // Create credentials structure
     credentials := scaleway.NewCredentials("foo@bar.com", "foobar")
 
     // Create new token
-    token, _, _ := client.Tokens.Create(credentials, true)
+    token, _, _ := client.Tokens.Create(ctx, credentials, true)
 
     // Use this token
 	client.AuthToken = token.ID
@@ -1,6 +1,11 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // ImagesService handles communication with the images related
 // methods of the Scaleway API.
@@ -19,11 +24,13 @@ type Image struct {
 	FromImage      string  `json:"from_image,omitempty"`
 	FromServer     string  `json:"from_server,omitempty"`
 	ID             string  `json:"id,omitempty"`
-	MarketplaceKey string  `json:"markeplace_key,omitempty"`
+	MarketplaceKey string  `json:"marketplace_key,omitempty"`
 	Name           string  `json:"name,omitempty"`
 	Organization   string  `json:"organization,omitempty"`
 	Public         bool    `json:"public,omitempty"`
 	RootVolume     *Volume `json:"root_volume,omitempty"`
+	// Region the image lives in. Only set by ListAllRegions.
+	Region Region `json:"-"`
 }
 
 // ImageRequest represents a request to create a image.
@@ -34,6 +41,22 @@ type ImageRequest struct {
 	RootVolume   string `json:"root_volume"`
 }
 
+// ImageListOptions specifies the optional parameters to the
+// ImagesService.List method. Filters are sent as query parameters and
+// also applied to the returned images, in case the API ignores them.
+type ImageListOptions struct {
+	// Arch filters images of the given architecture, e.g. "arm".
+	Arch string `url:"arch,omitempty"`
+	// Public filters public or private images when set.
+	Public *bool `url:"public,omitempty"`
+	// Organization filters images owned by the given organization.
+	Organization string `url:"organization,omitempty"`
+	// Name filters images whose name contains the given string.
+	Name string `url:"name,omitempty"`
+
+	ListOptions
+}
+
 // imageResponse represents a Scaleway image creation response.
 type imageResponse struct {
 	Image *Image `json:"image"`
@@ -45,7 +68,7 @@ type imageListResponse struct {
 }
 
 // Create creates images.
-func (s *ImagesService) Create(tr *ImageRequest) (*Image, *Response, error) {
+func (s *ImagesService) Create(ctx context.Context, tr *ImageRequest) (*Image, *Response, error) {
 	u := fmt.Sprintf("/images")
 	req, err := s.client.NewRequestCompute("POST", u, tr)
 	if err != nil {
@@ -53,7 +76,7 @@ func (s *ImagesService) Create(tr *ImageRequest) (*Image, *Response, error) {
 	}
 
 	image := new(imageResponse)
-	resp, err := s.client.Do(req, image)
+	resp, err := s.client.Do(ctx, req, image)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -61,27 +84,95 @@ func (s *ImagesService) Create(tr *ImageRequest) (*Image, *Response, error) {
 }
 
 // List returns a list of all images.
-func (s *ImagesService) List() ([]*Image, *Response, error) {
-	return s.listImages()
+func (s *ImagesService) List(ctx context.Context, opt *ImageListOptions) ([]*Image, *Response, error) {
+	return s.listImages(ctx, opt)
 }
 
-func (s *ImagesService) listImages() ([]*Image, *Response, error) {
-	u := fmt.Sprintf("/images")
+func (s *ImagesService) listImages(ctx context.Context, opt *ImageListOptions) ([]*Image, *Response, error) {
+	u, err := addOptions("/images", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	images := new(imageListResponse)
-	resp, err := s.client.Do(req, images)
+	resp, err := s.client.Do(ctx, req, images)
 	if err != nil {
 		return nil, nil, err
 	}
-	return images.Images, resp, nil
+	return opt.filter(images.Images), resp, nil
+}
+
+// ListPages calls fn for every page of images, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *ImagesService) ListPages(ctx context.Context, opt *ImageListOptions, fn func([]*Image) bool) error {
+	o := ImageListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		images, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(images), nil
+	})
+}
+
+// ListAll returns the images of every page, starting from the page set in opt.
+func (s *ImagesService) ListAll(ctx context.Context, opt *ImageListOptions) ([]*Image, error) {
+	var all []*Image
+	err := s.ListPages(ctx, opt, func(images []*Image) bool {
+		all = append(all, images...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ListAllRegions returns the images of every page in every region of
+// regions, or of all the Scaleway regions if regions is empty. Regions are
+// queried concurrently and the images are tagged with their region.
+func (s *ImagesService) ListAllRegions(ctx context.Context, regions []Region, opt *ImageListOptions) ([]*Image, error) {
+	if len(regions) == 0 {
+		regions = Regions()
+	}
+	results := make(map[Region][]*Image, len(regions))
+	var mu sync.Mutex
+	err := s.client.EachRegion(ctx, regions, func(ctx context.Context, c *Client) error {
+		images, err := c.Images.ListAll(ctx, opt)
+		if err != nil {
+			return err
+		}
+		for _, image := range images {
+			image.Region = c.Region
+		}
+		mu.Lock()
+		results[c.Region] = images
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Image
+	for _, region := range regions {
+		all = append(all, results[region]...)
+	}
+	return all, nil
 }
 
 // Get returns info for a specific image.
-func (s *ImagesService) Get(id string) (*Image, *Response, error) {
+func (s *ImagesService) Get(ctx context.Context, id string) (*Image, *Response, error) {
 	u := fmt.Sprintf("/images/%s", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
@@ -89,7 +180,7 @@ func (s *ImagesService) Get(id string) (*Image, *Response, error) {
 	}
 
 	image := new(imageResponse)
-	resp, err := s.client.Do(req, image)
+	resp, err := s.client.Do(ctx, req, image)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -97,15 +188,33 @@ func (s *ImagesService) Get(id string) (*Image, *Response, error) {
 }
 
 // Delete deletes a image.
-func (s *ImagesService) Delete(id string) (*Response, error) {
+func (s *ImagesService) Delete(ctx context.Context, id string) (*Response, error) {
 	u := fmt.Sprintf("/images/%s", id)
 	req, err := s.client.NewRequestCompute("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
+
+// filter returns the images matching the filters of opt.
+func (opt *ImageListOptions) filter(images []*Image) []*Image {
+	if opt == nil {
+		return images
+	}
+	filtered := images[:0]
+	for _, i := range images {
+		if !matchString(i.Arch, opt.Arch) ||
+			(opt.Public != nil && i.Public != *opt.Public) ||
+			!matchString(i.Organization, opt.Organization) ||
+			!strings.Contains(i.Name, opt.Name) {
+			continue
+		}
+		filtered = append(filtered, i)
+	}
+	return filtered
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -37,7 +38,7 @@ func TestImagesService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	image, _, err := client.Images.Create(inBody)
+	image, _, err := client.Images.Create(context.Background(), inBody)
 	if err != nil {
 		t.Errorf("Images.Create returned error: %v", err)
 	}
@@ -80,7 +81,7 @@ func TestImagesService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	images, _, err := client.Images.List()
+	images, _, err := client.Images.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Images.List returned error: %v", err)
 	}
@@ -131,7 +132,7 @@ func TestImagesService_Get(t *testing.T) {
 		//ExtraVolumes:     []string{},
 		FromImage:      "",
 		FromServer:     "",
-		MarketplaceKey: "",
+		MarketplaceKey: "1f0ad9d1-2f2c-4f47-8d25-3c1d5c4a2b6e",
 		Name:           "my_image",
 		Organization:   "000a115d-2852-4b0a-9ce8-47f1134ba95a",
 		Public:         false,
@@ -147,7 +148,7 @@ func TestImagesService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	image, _, err := client.Images.Get(want.ID)
+	image, _, err := client.Images.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Images.Get returned error: %v", err)
 	}
@@ -169,8 +170,39 @@ func TestImagesService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.Images.Delete(imageID)
+	_, err := client.Images.Delete(context.Background(), imageID)
 	if err != nil {
 		t.Errorf("Images.Delete returned error: %v", err)
 	}
 }
+
+func TestImagesService_List_filter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.RawQuery, "arch=arm&public=true"; got != want {
+			t.Errorf("Request query = %v, want %v", got, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"images": [
+			{"id": "1", "arch": "arm", "public": true},
+			{"id": "2", "arch": "x86_64", "public": true},
+			{"id": "3", "arch": "arm", "public": false}
+		]}`)
+	})
+
+	public := true
+	images, _, err := client.Images.List(context.Background(), &ImageListOptions{Arch: "arm", Public: &public})
+	if err != nil {
+		t.Errorf("Images.List returned error: %v", err)
+	}
+
+	want := []*Image{{ID: "1", Arch: "arm", Public: true}}
+	if !reflect.DeepEqual(images, want) {
+		t.Errorf("Images.List returned %+v\n, want %+v", images, want)
+	}
+}
@@ -1,6 +1,9 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // IPsService handles communication with the servers related
 // methods of the Scaleway API.
@@ -38,7 +41,7 @@ type ipListResponse struct {
 }
 
 // Create a reserved IP.
-func (s *IPsService) Create(ir *IPRequest) (*IP, *Response, error) {
+func (s *IPsService) Create(ctx context.Context, ir *IPRequest) (*IP, *Response, error) {
 	u := fmt.Sprintf("/ips")
 	req, err := s.client.NewRequestCompute("POST", u, ir)
 	if err != nil {
@@ -46,7 +49,7 @@ func (s *IPsService) Create(ir *IPRequest) (*IP, *Response, error) {
 	}
 
 	ip := new(ipResponse)
-	resp, err := s.client.Do(req, ip)
+	resp, err := s.client.Do(ctx, req, ip)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -54,27 +57,57 @@ func (s *IPsService) Create(ir *IPRequest) (*IP, *Response, error) {
 }
 
 // List returns a list of all reserved IPs.
-func (s *IPsService) List() ([]*IP, *Response, error) {
-	return s.listIPs()
+func (s *IPsService) List(ctx context.Context, opt *ListOptions) ([]*IP, *Response, error) {
+	return s.listIPs(ctx, opt)
 }
 
-func (s *IPsService) listIPs() ([]*IP, *Response, error) {
-	u := fmt.Sprintf("/ips")
+func (s *IPsService) listIPs(ctx context.Context, opt *ListOptions) ([]*IP, *Response, error) {
+	u, err := addOptions("/ips", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	ips := new(ipListResponse)
-	resp, err := s.client.Do(req, ips)
+	resp, err := s.client.Do(ctx, req, ips)
 	if err != nil {
 		return nil, nil, err
 	}
 	return ips.IPs, resp, nil
 }
 
+// ListPages calls fn for every page of reserved IPs, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *IPsService) ListPages(ctx context.Context, opt *ListOptions, fn func([]*IP) bool) error {
+	return walkPages(opt, func(opt *ListOptions) (*Response, bool, error) {
+		ips, resp, err := s.List(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(ips), nil
+	})
+}
+
+// ListAll returns the reserved IPs of every page, starting from the page set in opt.
+func (s *IPsService) ListAll(ctx context.Context, opt *ListOptions) ([]*IP, error) {
+	var all []*IP
+	err := s.ListPages(ctx, opt, func(ips []*IP) bool {
+		all = append(all, ips...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 // Get returns info for a specific reserved IP.
-func (s *IPsService) Get(id string) (*IP, *Response, error) {
+func (s *IPsService) Get(ctx context.Context, id string) (*IP, *Response, error) {
 	u := fmt.Sprintf("/ips/%s", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
@@ -82,7 +115,7 @@ func (s *IPsService) Get(id string) (*IP, *Response, error) {
 	}
 
 	ip := new(ipResponse)
-	resp, err := s.client.Do(req, ip)
+	resp, err := s.client.Do(ctx, req, ip)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -90,7 +123,7 @@ func (s *IPsService) Get(id string) (*IP, *Response, error) {
 }
 
 // Attach allow you to attach an IP to a server.
-func (s *IPsService) Attach(ir *IPRequest, id string) (*IP, *Response, error) {
+func (s *IPsService) Attach(ctx context.Context, ir *IPRequest, id string) (*IP, *Response, error) {
 	u := fmt.Sprintf("/ips/%s", id)
 	req, err := s.client.NewRequestCompute("PUT", u, ir)
 	if err != nil {
@@ -98,7 +131,7 @@ func (s *IPsService) Attach(ir *IPRequest, id string) (*IP, *Response, error) {
 	}
 
 	ip := new(ipResponse)
-	resp, err := s.client.Do(req, ip)
+	resp, err := s.client.Do(ctx, req, ip)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -106,13 +139,13 @@ func (s *IPsService) Attach(ir *IPRequest, id string) (*IP, *Response, error) {
 }
 
 // Delete deletes a reserved IP.
-func (s *IPsService) Delete(id string) (*Response, error) {
+func (s *IPsService) Delete(ctx context.Context, id string) (*Response, error) {
 	u := fmt.Sprintf("/ips/%s", id)
 	req, err := s.client.NewRequestCompute("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -33,7 +34,7 @@ func TestIPsService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	ip, _, err := client.IPs.Create(inBody)
+	ip, _, err := client.IPs.Create(context.Background(), inBody)
 	if err != nil {
 		t.Errorf("IPs.Create returned error: %v", err)
 	}
@@ -64,7 +65,7 @@ func TestIPsService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	ips, _, err := client.IPs.List()
+	ips, _, err := client.IPs.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("IPs.List returned error: %v", err)
 	}
@@ -102,7 +103,7 @@ func TestIPsService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	ip, _, err := client.IPs.Get(want.ID)
+	ip, _, err := client.IPs.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("IPs.Get returned error: %v", err)
 	}
@@ -139,7 +140,7 @@ func TestIPsService_Attach(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	ip, _, err := client.IPs.Attach(inBody, inBody.ID)
+	ip, _, err := client.IPs.Attach(context.Background(), inBody, inBody.ID)
 	if err != nil {
 		t.Errorf("IPs.Attach returned error: %v", err)
 	}
@@ -174,7 +175,7 @@ func TestIPsService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.IPs.Delete(ipID)
+	_, err := client.IPs.Delete(context.Background(), ipID)
 	if err != nil {
 		t.Errorf("IPs.Delete returned error: %v", err)
 	}
@@ -0,0 +1,205 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MarketplaceService handles communication with the marketplace API, which
+// publishes the images provided by Scaleway and its partners.
+//
+// Scaleway API docs: https://developer.scaleway.com/#marketplace
+type MarketplaceService struct {
+	client *Client
+}
+
+// MarketplaceImage represents an image of the marketplace, e.g.
+// "Ubuntu Xenial".
+type MarketplaceImage struct {
+	ID                   string                `json:"id,omitempty"`
+	Name                 string                `json:"name,omitempty"`
+	Label                string                `json:"label,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	Logo                 string                `json:"logo,omitempty"`
+	Categories           []string              `json:"categories,omitempty"`
+	CurrentPublicVersion string                `json:"current_public_version,omitempty"`
+	Versions             []*MarketplaceVersion `json:"versions,omitempty"`
+	CreationDate         Ntime                 `json:"creation_date,omitempty"`
+	ModificationDate     Ntime                 `json:"modification_date,omitempty"`
+}
+
+// MarketplaceVersion represents a version of a marketplace image.
+type MarketplaceVersion struct {
+	ID               string                   `json:"id,omitempty"`
+	Name             string                   `json:"name,omitempty"`
+	LocalImages      []*MarketplaceLocalImage `json:"local_images,omitempty"`
+	CreationDate     Ntime                    `json:"creation_date,omitempty"`
+	ModificationDate Ntime                    `json:"modification_date,omitempty"`
+}
+
+// MarketplaceLocalImage represents the compute image of a marketplace
+// version for a given architecture and zone.
+type MarketplaceLocalImage struct {
+	// ID is the ID of the compute image, as expected by ServerRequest.
+	ID   string `json:"id,omitempty"`
+	Arch string `json:"arch,omitempty"`
+	Zone string `json:"zone,omitempty"`
+}
+
+// marketplaceImageResponse represents a marketplace image response.
+type marketplaceImageResponse struct {
+	Image *MarketplaceImage `json:"image"`
+}
+
+// marketplaceImageListResponse represents a marketplace images list
+// response.
+type marketplaceImageListResponse struct {
+	Images []*MarketplaceImage `json:"images"`
+}
+
+// marketplaceVersionResponse represents a marketplace version response.
+type marketplaceVersionResponse struct {
+	Version *MarketplaceVersion `json:"version"`
+}
+
+// marketplaceVersionListResponse represents a marketplace versions list
+// response.
+type marketplaceVersionListResponse struct {
+	Versions []*MarketplaceVersion `json:"versions"`
+}
+
+// ListImages returns a list of all marketplace images.
+func (s *MarketplaceService) ListImages(ctx context.Context, opt *ListOptions) ([]*MarketplaceImage, *Response, error) {
+	u, err := addOptions("/images", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestMarketplace("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	images := new(marketplaceImageListResponse)
+	resp, err := s.client.Do(ctx, req, images)
+	if err != nil {
+		return nil, nil, err
+	}
+	return images.Images, resp, nil
+}
+
+// ListAllImages returns the marketplace images of every page, starting from
+// the page set in opt.
+func (s *MarketplaceService) ListAllImages(ctx context.Context, opt *ListOptions) ([]*MarketplaceImage, error) {
+	var all []*MarketplaceImage
+	err := walkPages(opt, func(opt *ListOptions) (*Response, bool, error) {
+		images, resp, err := s.ListImages(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		all = append(all, images...)
+		return resp, true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// GetImage returns info for a specific marketplace image.
+func (s *MarketplaceService) GetImage(ctx context.Context, id string) (*MarketplaceImage, *Response, error) {
+	u := fmt.Sprintf("/images/%s", id)
+	req, err := s.client.NewRequestMarketplace("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	image := new(marketplaceImageResponse)
+	resp, err := s.client.Do(ctx, req, image)
+	if err != nil {
+		return nil, nil, err
+	}
+	return image.Image, resp, nil
+}
+
+// ListVersions returns the versions of a marketplace image.
+func (s *MarketplaceService) ListVersions(ctx context.Context, imageID string) ([]*MarketplaceVersion, *Response, error) {
+	u := fmt.Sprintf("/images/%s/versions", imageID)
+	req, err := s.client.NewRequestMarketplace("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions := new(marketplaceVersionListResponse)
+	resp, err := s.client.Do(ctx, req, versions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions.Versions, resp, nil
+}
+
+// GetVersion returns info for a specific version of a marketplace image.
+func (s *MarketplaceService) GetVersion(ctx context.Context, imageID, id string) (*MarketplaceVersion, *Response, error) {
+	u := fmt.Sprintf("/images/%s/versions/%s", imageID, id)
+	req, err := s.client.NewRequestMarketplace("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version := new(marketplaceVersionResponse)
+	resp, err := s.client.Do(ctx, req, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return version.Version, resp, nil
+}
+
+// ResolveImage returns the ID of the compute image of the marketplace image
+// called name, e.g. "Ubuntu Xenial", for the architecture arch and the
+// region, e.g. RegionPar1. The current public version of the
+// image is used. Names are compared case-insensitively against both the
+// name and the label of the marketplace images.
+func (s *MarketplaceService) ResolveImage(ctx context.Context, name, arch string, region Region) (string, error) {
+	images, err := s.ListAllImages(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var image *MarketplaceImage
+	for _, i := range images {
+		if strings.EqualFold(i.Name, name) || strings.EqualFold(i.Label, name) {
+			image = i
+			break
+		}
+	}
+	if image == nil {
+		return "", fmt.Errorf("no marketplace image named %q", name)
+	}
+
+	version := image.currentVersion()
+	if version == nil {
+		return "", fmt.Errorf("marketplace image %q has no public version", name)
+	}
+	for _, l := range version.LocalImages {
+		if l.Arch == arch && l.Zone == string(region) {
+			return l.ID, nil
+		}
+	}
+	return "", fmt.Errorf("marketplace image %q has no %s image in %s", name, arch, region)
+}
+
+// currentVersion returns the current public version of the image, or its
+// most recent version when unknown.
+func (i *MarketplaceImage) currentVersion() *MarketplaceVersion {
+	var latest *MarketplaceVersion
+	for _, v := range i.Versions {
+		if v.ID == i.CurrentPublicVersion {
+			return v
+		}
+		if latest == nil || timeAfter(v.ModificationDate, latest.ModificationDate) {
+			latest = v
+		}
+	}
+	return latest
+}
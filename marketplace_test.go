@@ -0,0 +1,145 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarketplaceService_ListImages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "marketplace_images_list.json"))
+
+	mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	images, _, err := client.Marketplace.ListImages(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Marketplace.ListImages returned error: %v", err)
+	}
+
+	if got, want := len(images), 2; got != want {
+		t.Fatalf("Marketplace.ListImages returned %d images, want %d", got, want)
+	}
+	if got, want := images[0].Name, "Ubuntu Xenial"; got != want {
+		t.Errorf("Marketplace.ListImages returned name %q, want %q", got, want)
+	}
+	want := []*MarketplaceLocalImage{
+		{ID: "75c28f52-6c64-40fc-bb31-f53ca9d02de9", Arch: "x86_64", Zone: "par1"},
+		{ID: "a6d3d9d7-0b13-4f2a-b35e-5b1f0b1a3c0e", Arch: "arm", Zone: "par1"},
+		{ID: "f1a5bb7a-3f36-4d2b-a1c0-3a4e0f6b2c51", Arch: "x86_64", Zone: "ams1"},
+	}
+	if got := images[0].Versions[1].LocalImages; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marketplace.ListImages returned local images %+v, want %+v", got, want)
+	}
+}
+
+func TestMarketplaceService_GetImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "marketplace_images_get.json"))
+
+	mux.HandleFunc("/images/c4e3a8e4-5d9e-4a5b-9e2b-2d8e9b6f7a10", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	image, _, err := client.Marketplace.GetImage(context.Background(), "c4e3a8e4-5d9e-4a5b-9e2b-2d8e9b6f7a10")
+	if err != nil {
+		t.Fatalf("Marketplace.GetImage returned error: %v", err)
+	}
+
+	if got, want := image.Label, "docker"; got != want {
+		t.Errorf("Marketplace.GetImage returned label %q, want %q", got, want)
+	}
+	if got, want := image.Categories, []string{"instantapp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marketplace.GetImage returned categories %v, want %v", got, want)
+	}
+}
+
+func TestMarketplaceService_ListVersions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "marketplace_versions_list.json"))
+
+	mux.HandleFunc("/images/c4e3a8e4-5d9e-4a5b-9e2b-2d8e9b6f7a10/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	versions, _, err := client.Marketplace.ListVersions(context.Background(), "c4e3a8e4-5d9e-4a5b-9e2b-2d8e9b6f7a10")
+	if err != nil {
+		t.Fatalf("Marketplace.ListVersions returned error: %v", err)
+	}
+
+	want := []*MarketplaceLocalImage{{ID: "3b8e5c1d-7f2a-4e6b-9c0d-1a2b3c4d5e6f", Arch: "x86_64", Zone: "par1"}}
+	if len(versions) != 1 || !reflect.DeepEqual(versions[0].LocalImages, want) {
+		t.Errorf("Marketplace.ListVersions returned %+v", versions)
+	}
+}
+
+func TestMarketplaceService_ResolveImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "marketplace_images_list.json"))
+
+	mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	tests := []struct {
+		name, arch string
+		region     Region
+		want       string
+		wantErr    bool
+	}{
+		{"Ubuntu Xenial", "x86_64", RegionPar1, "75c28f52-6c64-40fc-bb31-f53ca9d02de9", false},
+		{"ubuntu xenial", "arm", RegionPar1, "a6d3d9d7-0b13-4f2a-b35e-5b1f0b1a3c0e", false},
+		{"ubuntu_xenial", "x86_64", RegionAms1, "f1a5bb7a-3f36-4d2b-a1c0-3a4e0f6b2c51", false},
+		{"Docker", "x86_64", RegionPar1, "3b8e5c1d-7f2a-4e6b-9c0d-1a2b3c4d5e6f", false},
+		{"Docker", "arm", RegionPar1, "", true},
+		{"Debian Jessie", "x86_64", RegionPar1, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := client.Marketplace.ResolveImage(context.Background(), tt.name, tt.arch, tt.region)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Marketplace.ResolveImage(%q, %q, %q) returned error: %v", tt.name, tt.arch, tt.region, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Marketplace.ResolveImage(%q, %q, %q) returned %q, want %q", tt.name, tt.arch, tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestMarketplaceImage_currentVersion(t *testing.T) {
+	older := &MarketplaceVersion{ID: "1", ModificationDate: Ntime(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))}
+	newer := &MarketplaceVersion{ID: "2", ModificationDate: Ntime(time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC))}
+
+	image := &MarketplaceImage{Versions: []*MarketplaceVersion{older, newer}}
+	if got := image.currentVersion(); got != newer {
+		t.Errorf("currentVersion returned %+v, want the latest version", got)
+	}
+
+	image.CurrentPublicVersion = "1"
+	if got := image.currentVersion(); got != older {
+		t.Errorf("currentVersion returned %+v, want the current public version", got)
+	}
+}
@@ -1,6 +1,9 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // OrganizationsService handles communication with the account API.
 type OrganizationsService struct {
@@ -9,9 +12,48 @@ type OrganizationsService struct {
 
 // Organization represents a Scaleway organization.
 type Organization struct {
-	ID    string  `json:"id,omitempty"`
-	Name  string  `json:"name,omitempty"`
-	Users []*User `json:"users,omitempty"`
+	ID     string  `json:"id,omitempty"`
+	Name   string  `json:"name,omitempty"`
+	Users  []*User `json:"users,omitempty"`
+	Quotas *Quotas `json:"quotas,omitempty"`
+}
+
+// Quotas represents the maximum number of resources an organization may
+// own.
+type Quotas struct {
+	Servers   int `json:"servers"`
+	IPs       int `json:"ips"`
+	Volumes   int `json:"volumes"`
+	Snapshots int `json:"snapshots"`
+	Images    int `json:"images"`
+}
+
+// ResourceUsage represents the usage of a resource type against its quota.
+type ResourceUsage struct {
+	Quota int
+	Used  int
+}
+
+// Remaining returns the number of resources that may still be created.
+func (r ResourceUsage) Remaining() int {
+	if r.Used >= r.Quota {
+		return 0
+	}
+	return r.Quota - r.Used
+}
+
+// Usage represents the usage of an organization per resource type.
+type Usage struct {
+	Servers   ResourceUsage
+	IPs       ResourceUsage
+	Volumes   ResourceUsage
+	Snapshots ResourceUsage
+	Images    ResourceUsage
+}
+
+// quotasResponse represents a Scaleway organization quotas response.
+type quotasResponse struct {
+	Quotas *Quotas `json:"quotas"`
 }
 
 // organizationListResponse represents a Scaleway organization list response.
@@ -20,11 +62,11 @@ type organizationListResponse struct {
 }
 
 // List returns a list of all organisation associate to your account.
-func (s *OrganizationsService) List() ([]*Organization, *Response, error) {
-	return s.listOrganizations()
+func (s *OrganizationsService) List(ctx context.Context) ([]*Organization, *Response, error) {
+	return s.listOrganizations(ctx)
 }
 
-func (s *OrganizationsService) listOrganizations() ([]*Organization, *Response, error) {
+func (s *OrganizationsService) listOrganizations(ctx context.Context) ([]*Organization, *Response, error) {
 	u := fmt.Sprintf("/organizations")
 	req, err := s.client.NewRequestAccount("GET", u, nil)
 	if err != nil {
@@ -32,10 +74,82 @@ func (s *OrganizationsService) listOrganizations() ([]*Organization, *Response,
 	}
 
 	organizations := new(organizationListResponse)
-	resp, err := s.client.Do(req, organizations)
+	resp, err := s.client.Do(ctx, req, organizations)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	return organizations.Organizations, resp, nil
 }
+
+// Quotas returns the quotas of a specific organization.
+func (s *OrganizationsService) Quotas(ctx context.Context, id string) (*Quotas, *Response, error) {
+	u := fmt.Sprintf("/organizations/%s/quotas", id)
+	req, err := s.client.NewRequestAccount("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quotas := new(quotasResponse)
+	resp, err := s.client.Do(ctx, req, quotas)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quotas.Quotas, resp, nil
+}
+
+// Usage returns the quotas of a specific organization along with the number
+// of resources it currently owns, as counted by listing them.
+func (s *OrganizationsService) Usage(ctx context.Context, id string) (*Usage, error) {
+	quotas, _, err := s.Quotas(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if quotas == nil {
+		quotas = new(Quotas)
+	}
+
+	usage := &Usage{
+		Servers:   ResourceUsage{Quota: quotas.Servers},
+		IPs:       ResourceUsage{Quota: quotas.IPs},
+		Volumes:   ResourceUsage{Quota: quotas.Volumes},
+		Snapshots: ResourceUsage{Quota: quotas.Snapshots},
+		Images:    ResourceUsage{Quota: quotas.Images},
+	}
+
+	servers, err := s.client.Servers.ListAll(ctx, &ServerListOptions{Organization: id})
+	if err != nil {
+		return nil, err
+	}
+	usage.Servers.Used = len(servers)
+
+	ips, err := s.client.IPs.ListAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if ip.Organization == id {
+			usage.IPs.Used++
+		}
+	}
+
+	volumes, err := s.client.Volumes.ListAll(ctx, &VolumeListOptions{Organization: id})
+	if err != nil {
+		return nil, err
+	}
+	usage.Volumes.Used = len(volumes)
+
+	snapshots, err := s.client.Snapshots.ListAll(ctx, &SnapshotListOptions{Organization: id})
+	if err != nil {
+		return nil, err
+	}
+	usage.Snapshots.Used = len(snapshots)
+
+	images, err := s.client.Images.ListAll(ctx, &ImageListOptions{Organization: id})
+	if err != nil {
+		return nil, err
+	}
+	usage.Images.Used = len(images)
+
+	return usage, nil
+}
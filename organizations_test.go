@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -23,7 +24,7 @@ func TestOrganizationService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	org, _, err := client.Organizations.List()
+	org, _, err := client.Organizations.List(context.Background())
 	if err != nil {
 		t.Errorf("Organizations.List returned error: %v", err)
 	}
@@ -39,7 +40,7 @@ func TestOrganizationService_List(t *testing.T) {
 					Fullname:   "John Snow",
 					ID:         "59a98700-8622-4495-a11a-e1efbfac5972",
 					Lastname:   "Snow",
-					SSHPubKeys: []string{},
+					SSHPubKeys: []*SSHKey{},
 				},
 			},
 		},
@@ -48,3 +49,86 @@ func TestOrganizationService_List(t *testing.T) {
 		t.Errorf("Organization.List returned %+v\n, want %+v", org, want)
 	}
 }
+
+func TestOrganizationService_Quotas(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	orgID := "000a115d-2852-4b0a-9ce8-47f1134ba95a"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "organizations_quotas.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/organizations/%s/quotas", orgID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	quotas, _, err := client.Organizations.Quotas(context.Background(), orgID)
+	if err != nil {
+		t.Errorf("Organizations.Quotas returned error: %v", err)
+	}
+
+	want := &Quotas{Servers: 10, IPs: 10, Volumes: 20, Snapshots: 20, Images: 100}
+	if !reflect.DeepEqual(quotas, want) {
+		t.Errorf("Organizations.Quotas returned %+v, want %+v", quotas, want)
+	}
+}
+
+func TestOrganizationService_Usage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	orgID := "000a115d-2852-4b0a-9ce8-47f1134ba95a"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "organizations_quotas.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/organizations/%s/quotas", orgID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(data))
+	})
+	mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("organization"); got != orgID {
+			t.Errorf("Request organization = %v, want %v", got, orgID)
+		}
+		fmt.Fprintf(w, `{"servers": [{"id": "1", "organization": %q}, {"id": "2", "organization": %q}]}`, orgID, orgID)
+	})
+	mux.HandleFunc("/ips", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"ips": [{"id": "1", "organization": %q}, {"id": "2", "organization": "other"}]}`, orgID)
+	})
+	mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"volumes": [{"id": "1", "organization": %q}, {"id": "2", "organization": %q}]}`, orgID, orgID)
+	})
+	mux.HandleFunc("/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"snapshots": []}`)
+	})
+	mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		// public images of other organizations must not be counted
+		fmt.Fprintf(w, `{"images": [{"id": "1", "organization": %q}, {"id": "2", "organization": "other", "public": true}]}`, orgID)
+	})
+
+	usage, err := client.Organizations.Usage(context.Background(), orgID)
+	if err != nil {
+		t.Fatalf("Organizations.Usage returned error: %v", err)
+	}
+
+	want := &Usage{
+		Servers:   ResourceUsage{Quota: 10, Used: 2},
+		IPs:       ResourceUsage{Quota: 10, Used: 1},
+		Volumes:   ResourceUsage{Quota: 20, Used: 2},
+		Snapshots: ResourceUsage{Quota: 20, Used: 0},
+		Images:    ResourceUsage{Quota: 100, Used: 1},
+	}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("Organizations.Usage returned %+v, want %+v", usage, want)
+	}
+	if got, want := usage.Servers.Remaining(), 8; got != want {
+		t.Errorf("Usage.Servers.Remaining() = %v, want %v", got, want)
+	}
+	if got, want := (ResourceUsage{Quota: 1, Used: 3}).Remaining(), 0; got != want {
+		t.Errorf("ResourceUsage.Remaining() over quota = %v, want %v", got, want)
+	}
+}
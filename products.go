@@ -0,0 +1,172 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Server type availabilities.
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityScarce    = "scarce"
+	AvailabilityShortage  = "shortage"
+)
+
+// ProductsService handles communication with the products catalog related
+// methods of the Scaleway API.
+//
+// Scaleway API docs: https://developer.scaleway.com/#products
+type ProductsService struct {
+	client *Client
+}
+
+// ServerType represents a server commercial type of the products catalog.
+type ServerType struct {
+	// Name is the commercial type, e.g. "VC1S".
+	Name              string                       `json:"-"`
+	AltNames          []string                     `json:"alt_names,omitempty"`
+	Arch              string                       `json:"arch,omitempty"`
+	NCPUs             int                          `json:"ncpus,omitempty"`
+	RAM               uint64                       `json:"ram,omitempty"`
+	Baremetal         bool                         `json:"baremetal,omitempty"`
+	HourlyPrice       float64                      `json:"hourly_price,omitempty"`
+	MonthlyPrice      float64                      `json:"monthly_price,omitempty"`
+	VolumesConstraint *ServerTypeVolumesConstraint `json:"volumes_constraint,omitempty"`
+	Network           *ServerTypeNetwork           `json:"network,omitempty"`
+	// Availability is one of "available", "scarce" or "shortage", when
+	// known.
+	Availability string `json:"-"`
+}
+
+// ServerTypeVolumesConstraint represents the total volumes size, in bytes,
+// a server type accepts.
+type ServerTypeVolumesConstraint struct {
+	MinSize uint64 `json:"min_size,omitempty"`
+	MaxSize uint64 `json:"max_size,omitempty"`
+}
+
+// ServerTypeNetwork represents the network capabilities of a server type.
+// Bandwidths are expressed in bits per second.
+type ServerTypeNetwork struct {
+	SumInternalBandwidth uint64 `json:"sum_internal_bandwidth,omitempty"`
+	SumInternetBandwidth uint64 `json:"sum_internet_bandwidth,omitempty"`
+	IPv6Support          bool   `json:"ipv6_support,omitempty"`
+}
+
+// ServerTypeRequirements specifies the constraints a server type must meet
+// to be picked by CheapestServerType.
+type ServerTypeRequirements struct {
+	// MinCPUs is the minimum number of CPUs.
+	MinCPUs int
+	// MinRAM is the minimum amount of RAM, in bytes.
+	MinRAM uint64
+	// Arch is the required architecture, e.g. "x86_64". Any when empty.
+	Arch string
+	// Baremetal requires baremetal or virtual servers when set.
+	Baremetal *bool
+	// Available excludes server types known to be in shortage.
+	Available bool
+}
+
+// serverTypesResponse represents a Scaleway server types catalog response.
+type serverTypesResponse struct {
+	Servers map[string]*ServerType `json:"servers"`
+}
+
+// serverTypesAvailabilityResponse represents a Scaleway server types
+// availability response.
+type serverTypesAvailabilityResponse struct {
+	Servers map[string]struct {
+		Availability string `json:"availability"`
+	} `json:"servers"`
+}
+
+// ListServerTypes returns the server types of the catalog, sorted by name.
+func (s *ProductsService) ListServerTypes(ctx context.Context) ([]*ServerType, *Response, error) {
+	u := fmt.Sprintf("/products/servers")
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalog := new(serverTypesResponse)
+	resp, err := s.client.Do(ctx, req, catalog)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types := make([]*ServerType, 0, len(catalog.Servers))
+	for name, t := range catalog.Servers {
+		t.Name = name
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types, resp, nil
+}
+
+// Availability returns the availability of every server type, by name.
+func (s *ProductsService) Availability(ctx context.Context) (map[string]string, *Response, error) {
+	u := fmt.Sprintf("/products/servers/availability")
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	availability := new(serverTypesAvailabilityResponse)
+	resp, err := s.client.Do(ctx, req, availability)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := make(map[string]string, len(availability.Servers))
+	for name, a := range availability.Servers {
+		m[name] = a.Availability
+	}
+	return m, resp, nil
+}
+
+// CheapestServerType returns the cheapest server type of the catalog
+// meeting the requirements of r, along with its availability.
+func (s *ProductsService) CheapestServerType(ctx context.Context, r *ServerTypeRequirements) (*ServerType, error) {
+	types, _, err := s.ListServerTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	availability, _, err := s.Availability(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		t.Availability = availability[t.Name]
+	}
+
+	t := CheapestServerType(types, r)
+	if t == nil {
+		return nil, fmt.Errorf("no server type meets the requirements %+v", r)
+	}
+	return t, nil
+}
+
+// CheapestServerType returns the server type of types with the lowest
+// hourly price meeting the requirements of r, or nil if none does.
+func CheapestServerType(types []*ServerType, r *ServerTypeRequirements) *ServerType {
+	if r == nil {
+		r = new(ServerTypeRequirements)
+	}
+
+	var cheapest *ServerType
+	for _, t := range types {
+		if t.NCPUs < r.MinCPUs || t.RAM < r.MinRAM ||
+			!matchString(t.Arch, r.Arch) ||
+			(r.Baremetal != nil && t.Baremetal != *r.Baremetal) ||
+			(r.Available && t.Availability == AvailabilityShortage) {
+			continue
+		}
+		if cheapest == nil || t.HourlyPrice < cheapest.HourlyPrice ||
+			(t.HourlyPrice == cheapest.HourlyPrice && t.MonthlyPrice < cheapest.MonthlyPrice) {
+			cheapest = t
+		}
+	}
+	return cheapest
+}
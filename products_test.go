@@ -0,0 +1,133 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProductsService_ListServerTypes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "products_servers.json"))
+
+	mux.HandleFunc("/products/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	types, _, err := client.Products.ListServerTypes(context.Background())
+	if err != nil {
+		t.Fatalf("Products.ListServerTypes returned error: %v", err)
+	}
+
+	var names []string
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	if want := []string{"C2S", "VC1M", "VC1S"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Products.ListServerTypes returned %v, want %v", names, want)
+	}
+
+	want := &ServerType{
+		Name:         "VC1S",
+		AltNames:     []string{"X64-2GB"},
+		Arch:         "x86_64",
+		NCPUs:        2,
+		RAM:          2147483648,
+		HourlyPrice:  0.006,
+		MonthlyPrice: 2.99,
+		VolumesConstraint: &ServerTypeVolumesConstraint{
+			MinSize: 50000000000,
+			MaxSize: 50000000000,
+		},
+		Network: &ServerTypeNetwork{
+			SumInternalBandwidth: 200000000,
+			SumInternetBandwidth: 200000000,
+			IPv6Support:          true,
+		},
+	}
+	if !reflect.DeepEqual(types[2], want) {
+		t.Errorf("Products.ListServerTypes returned %+v, want %+v", types[2], want)
+	}
+}
+
+func TestProductsService_Availability(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "products_servers_availability.json"))
+
+	mux.HandleFunc("/products/servers/availability", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	availability, _, err := client.Products.Availability(context.Background())
+	if err != nil {
+		t.Errorf("Products.Availability returned error: %v", err)
+	}
+
+	want := map[string]string{
+		"C2S":  AvailabilityAvailable,
+		"VC1M": AvailabilityShortage,
+		"VC1S": AvailabilityScarce,
+	}
+	if !reflect.DeepEqual(availability, want) {
+		t.Errorf("Products.Availability returned %v, want %v", availability, want)
+	}
+}
+
+func TestProductsService_CheapestServerType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	types := testOpenFixture(t, filepath.Join(fixtureDir, "products_servers.json"))
+	availability := testOpenFixture(t, filepath.Join(fixtureDir, "products_servers_availability.json"))
+
+	mux.HandleFunc("/products/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(types))
+	})
+	mux.HandleFunc("/products/servers/availability", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(availability))
+	})
+
+	tests := []struct {
+		req  *ServerTypeRequirements
+		want string
+	}{
+		{nil, "VC1S"},
+		{&ServerTypeRequirements{MinCPUs: 4}, "VC1M"},
+		{&ServerTypeRequirements{MinCPUs: 4, Available: true}, "C2S"},
+		{&ServerTypeRequirements{MinRAM: 3 << 30, Baremetal: Bool(true)}, "C2S"},
+		{&ServerTypeRequirements{Arch: "arm"}, ""},
+	}
+	for _, tt := range tests {
+		st, err := client.Products.CheapestServerType(context.Background(), tt.req)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Products.CheapestServerType(%+v) = %v, want error", tt.req, st.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Products.CheapestServerType(%+v) returned error: %v", tt.req, err)
+			continue
+		}
+		if st.Name != tt.want {
+			t.Errorf("Products.CheapestServerType(%+v) = %v, want %v", tt.req, st.Name, tt.want)
+		}
+	}
+}
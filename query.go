@@ -0,0 +1,122 @@
+package scaleway
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// addOptions adds the parameters in opt as URL query parameters to s. opt
+// must be a struct (or a pointer to a struct) whose fields are tagged with
+// `url:"name[,omitempty]"`. Embedded structs are flattened.
+func addOptions(s string, opt interface{}) (string, error) {
+	v := reflect.ValueOf(opt)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return s, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+
+	qs := u.Query()
+	encodeValues(qs, reflect.Indirect(v))
+	u.RawQuery = qs.Encode()
+	return u.String(), nil
+}
+
+// encodeValues encodes the tagged fields of the struct v into qs.
+func encodeValues(qs url.Values, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		if sf.Anonymous && fv.Kind() == reflect.Struct {
+			encodeValues(qs, fv)
+			continue
+		}
+
+		tag := sf.Tag.Get("url")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name, omitEmpty := tag, false
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, omitEmpty = tag[:i], strings.Contains(tag[i:], "omitempty")
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				qs.Add(name, formatValue(fv.Index(j)))
+			}
+		default:
+			qs.Set(name, formatValue(fv))
+		}
+	}
+}
+
+// formatValue returns the query string representation of v.
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return ""
+}
+
+// isEmptyValue reports whether v is the zero value of its type.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	}
+	return false
+}
+
+// matchString reports whether got matches the filter want. An empty filter
+// matches everything.
+func matchString(got, want string) bool {
+	return want == "" || got == want
+}
+
+// matchTags reports whether got contains every tag of want.
+func matchTags(got, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package scaleway
+
+import "testing"
+
+type queryOptions struct {
+	ListOptions
+	Name   string   `url:"name,omitempty"`
+	Tags   []string `url:"tags,omitempty"`
+	Public *bool    `url:"public,omitempty"`
+	Count  int      `url:"count"`
+	Skip   string
+}
+
+type addOptionsTest struct {
+	in      string
+	opt     interface{}
+	out     string
+	wantErr bool
+}
+
+var addOptionsTests = []addOptionsTest{
+	{"/servers", (*ListOptions)(nil), "/servers", false},
+	{"/servers", &ListOptions{}, "/servers", false},
+	{"/servers", &ListOptions{Page: 2, PerPage: 50}, "/servers?page=2&per_page=50", false},
+	{"/servers?a=b", &ListOptions{Page: 2}, "/servers?a=b&page=2", false},
+	{"/servers", &queryOptions{Name: "foo", Tags: []string{"a", "b"}, Public: new(bool), Skip: "x"},
+		"/servers?count=0&name=foo&public=false&tags=a&tags=b", false},
+	{"%zzzzzz", &ListOptions{Page: 2}, "%zzzzzz", true},
+}
+
+func TestAddOptions(t *testing.T) {
+	for _, tt := range addOptionsTests {
+		got, err := addOptions(tt.in, tt.opt)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("addOptions(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.out {
+			t.Errorf("addOptions(%q, %+v) = %q, want %q", tt.in, tt.opt, got, tt.out)
+		}
+	}
+}
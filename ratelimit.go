@@ -0,0 +1,183 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateReset     = "X-RateLimit-Reset"
+)
+
+// Rate represents the rate limit reported by the API for the current client.
+type Rate struct {
+	// The number of requests per window the client is allowed to make.
+	Limit int
+	// The number of remaining requests the client can make in the window.
+	Remaining int
+	// The time at which the current rate limit window resets.
+	Reset time.Time
+}
+
+// parseRate parses the rate limit headers of r. Missing headers leave the
+// matching fields to their zero value.
+func parseRate(r *http.Response) Rate {
+	var rate Rate
+	if limit := r.Header.Get(headerRateLimit); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := r.Header.Get(headerRateRemaining); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+	if reset := r.Header.Get(headerRateReset); reset != "" {
+		if v, _ := strconv.ParseInt(reset, 10, 64); v != 0 {
+			rate.Reset = time.Unix(v, 0)
+		}
+	}
+	return rate
+}
+
+// RateLimitError occurs when a request is refused by a RateLimiter
+// configured to fail fast.
+type RateLimitError struct {
+	// Endpoint is the API endpoint, e.g. "https://api.scaleway.com".
+	Endpoint string
+	// RetryAfter is the delay after which a request would be allowed.
+	RetryAfter time.Duration
+}
+
+func (r *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %v, retry after %v", r.Endpoint, r.RetryAfter)
+}
+
+// IsRateLimited reports whether err was caused by a client-side RateLimiter
+// or by a 429 Too Many Requests response.
+func IsRateLimited(err error) bool {
+	if _, ok := err.(*RateLimitError); ok {
+		return true
+	}
+	e, ok := errorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusTooManyRequests
+}
+
+// A RateLimiter paces outgoing requests with a token bucket per API
+// endpoint, so that the account and compute APIs are limited separately.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	// FailFast makes requests fail with a *RateLimitError instead of
+	// blocking until the bucket allows them.
+	FailFast bool
+
+	rate  float64 // tokens added per second
+	burst int     // bucket capacity
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is the token bucket of a single endpoint.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second
+// per endpoint, with bursts of at most burst requests. A rate lower than or
+// equal to 0 never refills the buckets: once burst requests have been made
+// to an endpoint, the following ones fail with a *RateLimitError, even if
+// FailFast is false.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Wait blocks until a request to endpoint is allowed, ctx is done, or, if
+// the RateLimiter fails fast, returns a *RateLimitError right away.
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	wait, err := l.reserve(endpoint, time.Now())
+	if err != nil || wait <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.release(endpoint)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// release gives back to the bucket of endpoint a token reserved by a request
+// that was finally not sent.
+func (l *RateLimiter) release(endpoint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[endpoint]; ok {
+		b.tokens++
+		if b.tokens > float64(l.burst) {
+			b.tokens = float64(l.burst)
+		}
+	}
+}
+
+// reserve takes a token from the bucket of endpoint and returns the delay
+// after which it is available.
+func (l *RateLimiter) reserve(endpoint string, now time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[endpoint]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[endpoint] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+	if l.rate <= 0 {
+		return 0, &RateLimitError{Endpoint: endpoint}
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	if l.FailFast {
+		return 0, &RateLimitError{Endpoint: endpoint, RetryAfter: wait}
+	}
+	b.tokens--
+	return wait, nil
+}
+
+// roundTrip sends a single attempt of req, once the RateLimiter of the
+// client allows it.
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.RateLimiter != nil {
+		endpoint := req.URL.Scheme + "://" + req.URL.Host
+		if err := c.RateLimiter.Wait(ctx, endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return c.client.Do(req)
+}
@@ -0,0 +1,121 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDo_rateHeaders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "60")
+		w.Header().Set(headerRateRemaining, "59")
+		w.Header().Set(headerRateReset, "1372700873")
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+
+	want := Rate{Limit: 60, Remaining: 59, Reset: time.Unix(1372700873, 0)}
+	if resp.Rate != want {
+		t.Errorf("Response.Rate = %+v, want %+v", resp.Rate, want)
+	}
+}
+
+func TestDo_rateLimiterFailFast(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	client.RateLimiter = NewRateLimiter(0.001, 1)
+	client.RateLimiter.FailFast = true
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	req, _ = client.NewRequestCompute("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("Do returned error %v, want a rate limit error", err)
+	}
+	if calls != 1 {
+		t.Errorf("Do sent %d requests, want 1", calls)
+	}
+}
+
+func TestRateLimiter_reserve(t *testing.T) {
+	l := NewRateLimiter(2, 2)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if wait, err := l.reserve("compute", now); wait != 0 || err != nil {
+			t.Errorf("reserve #%d = %v, %v, want 0, nil", i, wait, err)
+		}
+	}
+	if wait, err := l.reserve("compute", now); wait != 500*time.Millisecond || err != nil {
+		t.Errorf("reserve over burst = %v, %v, want 500ms, nil", wait, err)
+	}
+
+	// Endpoints have their own bucket.
+	if wait, err := l.reserve("account", now); wait != 0 || err != nil {
+		t.Errorf("reserve on another endpoint = %v, %v, want 0, nil", wait, err)
+	}
+
+	// Tokens are refilled with time.
+	if wait, err := l.reserve("compute", now.Add(time.Second)); wait != 0 || err != nil {
+		t.Errorf("reserve after refill = %v, %v, want 0, nil", wait, err)
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.Wait(context.Background(), "compute")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, "compute"); err != context.Canceled {
+		t.Errorf("Wait returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRateLimiter_Wait_canceledReleasesToken(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	now := time.Now()
+	l.reserve("compute", now)
+
+	// Canceled waits give their token back, so they don't delay the
+	// following requests.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		l.Wait(ctx, "compute")
+	}
+
+	wait, err := l.reserve("compute", now)
+	if err != nil || wait > time.Second {
+		t.Errorf("reserve after canceled waits = %v, %v, want at most 1s", wait, err)
+	}
+}
+
+func TestRateLimiter_zeroRate(t *testing.T) {
+	l := NewRateLimiter(0, 1)
+	l.Wait(context.Background(), "compute")
+
+	if err := l.Wait(context.Background(), "compute"); !IsRateLimited(err) {
+		t.Errorf("Wait over burst with a zero rate returned %v, want a *RateLimitError", err)
+	}
+}
@@ -0,0 +1,119 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// Region identifies a Scaleway region, e.g. "par1". The compute API of each
+// region is served by its own endpoint, and regions double as the zones
+// reported by the marketplace API.
+type Region string
+
+// Scaleway regions.
+const (
+	RegionPar1 Region = "par1"
+	RegionAms1 Region = "ams1"
+)
+
+// regionComputeURLs maps each region to the base URL of its compute API.
+var regionComputeURLs = map[Region]string{
+	RegionPar1: "https://cp-par1.scaleway.com/",
+	RegionAms1: "https://cp-ams1.scaleway.com/",
+}
+
+// Regions returns all the Scaleway regions.
+func Regions() []Region {
+	return []Region{RegionPar1, RegionAms1}
+}
+
+// ComputeURL returns the base URL of the compute API of the region.
+func (r Region) ComputeURL() (*url.URL, error) {
+	u, ok := regionComputeURLs[r]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q", r)
+	}
+	return url.Parse(u)
+}
+
+// RegionError wraps an error that occurred in a given region.
+type RegionError struct {
+	Region Region
+	Err    error
+}
+
+func (e *RegionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Region, e.Err)
+}
+
+// SetRegion routes the compute requests of the client to region.
+func (c *Client) SetRegion(region Region) error {
+	u, err := region.ComputeURL()
+	if err != nil {
+		return err
+	}
+	c.Region = region
+	c.ComputeBaseURL = u
+	return nil
+}
+
+// ForRegion returns a copy of the client whose compute requests are routed
+// to region. The copy shares the HTTP client, RetryPolicy and RateLimiter of
+// c:
+//
+//	ams, err := client.ForRegion(scaleway.RegionAms1)
+//	if err != nil {
+//		return err
+//	}
+//	servers, _, err := ams.Servers.List(ctx, nil)
+func (c *Client) ForRegion(region Region) (*Client, error) {
+	rc := *c
+	if err := rc.SetRegion(region); err != nil {
+		return nil, err
+	}
+	rc.initServices()
+	return &rc, nil
+}
+
+// EachRegion calls fn concurrently with a client for every region of
+// regions, or of all the Scaleway regions if regions is empty. The context
+// passed to fn is canceled as soon as one call fails, and the first error
+// is returned as a *RegionError.
+func (c *Client) EachRegion(ctx context.Context, regions []Region, fn func(ctx context.Context, c *Client) error) error {
+	if len(regions) == 0 {
+		regions = Regions()
+	}
+	clients := make([]*Client, len(regions))
+	for i, region := range regions {
+		rc, err := c.ForRegion(region)
+		if err != nil {
+			return err
+		}
+		clients[i] = rc
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for _, rc := range clients {
+		wg.Add(1)
+		go func(rc *Client) {
+			defer wg.Done()
+			if err := fn(ctx, rc); err != nil {
+				once.Do(func() {
+					firstErr = &RegionError{Region: rc.Region, Err: err}
+					cancel()
+				})
+			}
+		}(rc)
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,141 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// setupRegions starts a test server per region and routes the regional
+// compute endpoints to them. The returned function restores them.
+func setupRegions(handlers map[Region]http.HandlerFunc) func() {
+	saved := regionComputeURLs
+	regionComputeURLs = make(map[Region]string)
+
+	var servers []*httptest.Server
+	for region, h := range handlers {
+		s := httptest.NewServer(h)
+		servers = append(servers, s)
+		regionComputeURLs[region] = s.URL + "/"
+	}
+	return func() {
+		for _, s := range servers {
+			s.Close()
+		}
+		regionComputeURLs = saved
+	}
+}
+
+func TestRegion_ComputeURL(t *testing.T) {
+	u, err := RegionAms1.ComputeURL()
+	if err != nil {
+		t.Fatalf("ComputeURL returned error: %v", err)
+	}
+	if got, want := u.String(), "https://cp-ams1.scaleway.com/"; got != want {
+		t.Errorf("ComputeURL returned %v, want %v", got, want)
+	}
+
+	if _, err := Region("xxx1").ComputeURL(); err == nil {
+		t.Error("ComputeURL of an unknown region returned no error")
+	}
+}
+
+func TestClient_ForRegion(t *testing.T) {
+	c := NewClient(nil)
+	c.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	rc, err := c.ForRegion(RegionAms1)
+	if err != nil {
+		t.Fatalf("ForRegion returned error: %v", err)
+	}
+
+	if got, want := rc.Region, RegionAms1; got != want {
+		t.Errorf("ForRegion Region is %v, want %v", got, want)
+	}
+	if got, want := rc.ComputeBaseURL.String(), "https://cp-ams1.scaleway.com/"; got != want {
+		t.Errorf("ForRegion ComputeBaseURL is %v, want %v", got, want)
+	}
+	if got, want := rc.AuthToken, c.AuthToken; got != want {
+		t.Errorf("ForRegion AuthToken is %v, want %v", got, want)
+	}
+	if rc.Servers.client != rc {
+		t.Error("ForRegion services are not bound to the new client")
+	}
+	if got, want := c.ComputeBaseURL.String(), defaultComputeBaseURL; got != want {
+		t.Errorf("ForRegion changed ComputeBaseURL of the original client to %v", got)
+	}
+
+	if _, err := c.ForRegion(Region("xxx1")); err == nil {
+		t.Error("ForRegion of an unknown region returned no error")
+	}
+}
+
+func TestClient_EachRegion_error(t *testing.T) {
+	boom := errors.New("boom")
+	err := NewClient(nil).EachRegion(context.Background(), nil, func(ctx context.Context, c *Client) error {
+		if c.Region == RegionAms1 {
+			return boom
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	e, ok := err.(*RegionError)
+	if !ok {
+		t.Fatalf("EachRegion returned %v, want a *RegionError", err)
+	}
+	if e.Region != RegionAms1 || e.Err != boom {
+		t.Errorf("EachRegion returned %+v, want the error of %v", e, RegionAms1)
+	}
+}
+
+func TestServersService_ListAllRegions(t *testing.T) {
+	handler := func(id string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			if r.URL.Path != "/servers" {
+				t.Errorf("Request path: %v, want /servers", r.URL.Path)
+			}
+			w.Header().Add("Content-Type", contentType)
+			fmt.Fprintf(w, `{"servers": [{"id": %q}]}`, id)
+		}
+	}
+	defer setupRegions(map[Region]http.HandlerFunc{
+		RegionPar1: handler("par1-server"),
+		RegionAms1: handler("ams1-server"),
+	})()
+
+	servers, err := NewClient(nil).Servers.ListAllRegions(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("Servers.ListAllRegions returned error: %v", err)
+	}
+
+	want := []*Server{
+		{ID: "par1-server", Region: RegionPar1},
+		{ID: "ams1-server", Region: RegionAms1},
+	}
+	if !reflect.DeepEqual(servers, want) {
+		t.Errorf("Servers.ListAllRegions returned %+v, want %+v", servers, want)
+	}
+}
+
+func TestVolumesService_ListAllRegions_error(t *testing.T) {
+	defer setupRegions(map[Region]http.HandlerFunc{
+		RegionPar1: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", contentType)
+			fmt.Fprint(w, `{"volumes": []}`)
+		},
+		RegionAms1: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	})()
+
+	_, err := NewClient(nil).Volumes.ListAllRegions(context.Background(), nil, nil)
+	if e, ok := err.(*RegionError); !ok || e.Region != RegionAms1 {
+		t.Errorf("Volumes.ListAllRegions returned %v, want a *RegionError for %v", err, RegionAms1)
+	}
+}
@@ -0,0 +1,154 @@
+package scaleway
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A RetryPolicy configures how Client.Do retries requests failing with a
+// transient error: a network error, a 429 Too Many Requests or a 502, 503
+// or 504 status code.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, the first one
+	// included. Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles after
+	// every attempt, with a random jitter.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. A Retry-After header
+	// sent by the API takes precedence over the computed delay.
+	MaxBackoff time.Duration
+	// RetryPOST enables retries for non idempotent requests (POST and
+	// PATCH). By default only GET, HEAD, PUT, DELETE and OPTIONS requests
+	// are retried.
+	RetryPOST bool
+	// OnRetry, if set, is called before waiting for each retry. resp or err
+	// is the outcome of the failed attempt.
+	OnRetry func(attempt int, req *http.Request, resp *http.Response, err error, wait time.Duration)
+}
+
+// NewRetryPolicy returns a RetryPolicy making up to 4 attempts, waiting
+// between 500ms and 30s between two of them.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// retryable reports whether req may be sent again according to p.
+func (p *RetryPolicy) retryable(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return p.RetryPOST
+}
+
+// backoff returns the delay to wait before the given retry attempt
+// (starting at 1), honoring the Retry-After header of resp.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Full jitter on the upper half, to spread concurrent clients.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, either expressed in seconds
+// or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := t.Sub(time.Now())
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// shouldRetry reports whether the outcome of an attempt is a transient
+// failure.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// send sends req, retrying it according to the RetryPolicy of the client.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	p := c.RetryPolicy
+	if !p.retryable(req) {
+		return c.roundTrip(ctx, req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.roundTrip(ctx, req)
+		if attempt >= p.MaxAttempts || !shouldRetry(resp, err) || ctx.Err() != nil || IsRateLimited(err) {
+			return resp, err
+		}
+
+		wait := p.backoff(attempt, resp)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, req, resp, err, wait)
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDo_retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var retries []int
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		OnRetry: func(attempt int, req *http.Request, resp *http.Response, err error, wait time.Duration) {
+			retries = append(retries, resp.StatusCode)
+		},
+	}
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), `{"A":"a"}`+"\n"; got != want {
+			t.Errorf("Request body = %q, want %q", got, want)
+		}
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"A":"a"}`)
+		}
+	})
+
+	req, _ := client.NewRequestCompute("PUT", "/", inBody{A: "a"})
+	_, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Do sent %d requests, want 3", calls)
+	}
+	if len(retries) != 2 || retries[0] != 503 || retries[1] != 429 {
+		t.Errorf("OnRetry called with %v, want [503 429]", retries)
+	}
+}
+
+func TestDo_retryExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2}
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequestCompute("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if err == nil {
+		t.Error("Expected HTTP 502 error.")
+	}
+	if calls != 2 {
+		t.Errorf("Do sent %d requests, want 2", calls)
+	}
+}
+
+func TestDo_retryPOST(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3}
+	req, _ := client.NewRequestCompute("POST", "/", inBody{A: "a"})
+	client.Do(context.Background(), req, nil)
+	if calls != 1 {
+		t.Errorf("Do sent %d POST requests, want 1", calls)
+	}
+
+	calls = 0
+	client.RetryPolicy.RetryPOST = true
+	req, _ = client.NewRequestCompute("POST", "/", inBody{A: "a"})
+	client.Do(context.Background(), req, nil)
+	if calls != 3 {
+		t.Errorf("Do sent %d POST requests with RetryPOST, want 3", calls)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		got := p.backoff(attempt+1, nil)
+		if got < max/2 || got > max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt+1, got, max/2, max)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"7"}}}
+	if got, want := p.backoff(1, resp), 7*time.Second; got != want {
+		t.Errorf("backoff with Retry-After = %v, want %v", got, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got, ok := parseRetryAfter("120"); !ok || got != 2*time.Minute {
+		t.Errorf("parseRetryAfter(120) = %v, %v, want 2m, true", got, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got, ok := parseRetryAfter(date); !ok || got <= 58*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, %v, want about 1h, true", date, got, ok)
+	}
+	for _, v := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(v); ok {
+			t.Errorf("parseRetryAfter(%q) succeeded, want failure", v)
+		}
+	}
+}
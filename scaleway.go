@@ -2,10 +2,15 @@ package scaleway
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +18,7 @@ const (
 	libraryVersion        = "0.1"
 	defaultAccountBaseURL = "https://account.scaleway.com/"
 	defaultComputeBaseURL = "https://api.scaleway.com/"
+	defaultMarketplaceURL = "https://api-marketplace.scaleway.com/"
 	userAgent             = "go-scaleway/" + libraryVersion
 	contentType           = "application/json"
 )
@@ -25,20 +31,43 @@ type Client struct {
 	AccountBaseURL *url.URL
 	// Base URL related to compute actions.
 	ComputeBaseURL *url.URL
+	// Region of the compute API, as set by SetRegion. Empty when
+	// ComputeBaseURL is not a regional endpoint.
+	Region Region
+	// Base URL related to marketplace actions.
+	MarketplaceBaseURL *url.URL
 	// UserAgent used when communicating with the Scaleway API.
 	UserAgent string
-	// AuthToken used when communication with Scaleway API.
+	// AuthToken used when communication with Scaleway API, unless an
+	// Authenticator is set.
 	AuthToken string
+	// Authenticator, if set, authenticates requests instead of AuthToken,
+	// e.g. a *TokenSource or an *APIKey.
+	Authenticator Authenticator
+	// Organization is the default organization of the client, as read by
+	// NewClientFromEnv and NewClientFromConfig.
+	Organization string
+	// RetryPolicy used to retry requests failing with a transient error.
+	// A nil RetryPolicy disables retries.
+	RetryPolicy *RetryPolicy
+	// RateLimiter used to pace outgoing requests. A nil RateLimiter
+	// disables client-side rate limiting.
+	RateLimiter *RateLimiter
 	// Services used for talking to Scaleway API.
-	Tokens        *TokensService
-	Organizations *OrganizationsService
-	Users         *UsersService
-	Volumes       *VolumesService
-	Snapshots     *SnapshotsService
-	Images        *ImagesService
-	Servers       *ServersService
-	Actions       *ActionsService
-	IPs           *IPsService
+	Tokens         *TokensService
+	Organizations  *OrganizationsService
+	Users          *UsersService
+	Volumes        *VolumesService
+	Snapshots      *SnapshotsService
+	Images         *ImagesService
+	Servers        *ServersService
+	Actions        *ActionsService
+	IPs            *IPsService
+	Tasks          *TasksService
+	SecurityGroups *SecurityGroupsService
+	Bootscripts    *BootscriptsService
+	Products       *ProductsService
+	Marketplace    *MarketplaceService
 }
 
 // timeLayout represents the time layout needed for parsing.
@@ -61,9 +90,50 @@ func (t *Ntime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// Response is a Scaleway API Response. This wrap the standard http.Response.
+// MarshalJSON encodes JSON custom time in the layout of the API, the zero
+// time being encoded as null, so that the types of this package holding
+// dates encode as they were decoded. Ntime values used to encode as {}.
+func (t Ntime) MarshalJSON() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + time.Time(t).Format(timeLayout) + `"`), nil
+}
+
+// timeAfter reports whether the time instant t is after u.
+func timeAfter(t, u Ntime) bool {
+	return time.Time(t).After(time.Time(u))
+}
+
+// ListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ListOptions struct {
+	// For paginated result sets, page of results to retrieve.
+	Page int `url:"page,omitempty"`
+	// For paginated result sets, the number of results to include per page.
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// Response is a Scaleway API Response. This wrap the standard http.Response
+// and provides convenient access to the pagination headers.
 type Response struct {
 	*http.Response
+
+	// These fields provide the page values for paginating through a set of
+	// results. Any or all of these may be set to the zero value for
+	// responses that are not part of a paginated set, or for which there
+	// are no additional pages.
+	NextPage  int
+	PrevPage  int
+	FirstPage int
+	LastPage  int
+
+	// TotalCount is the total number of items of the paginated set, as
+	// reported by the X-Total-Count header.
+	TotalCount int
+
+	// Rate is the rate limit reported by the API, if any.
+	Rate Rate
 }
 
 // NewClient returns a new Scaleway API Client. If a nil httpClient
@@ -74,11 +144,19 @@ func NewClient(httpClient *http.Client) *Client {
 	}
 	accountBaseURL, _ := url.Parse(defaultAccountBaseURL)
 	computeBaseURL, _ := url.Parse(defaultComputeBaseURL)
+	marketplaceBaseURL, _ := url.Parse(defaultMarketplaceURL)
 
 	c := &Client{client: httpClient,
-		AccountBaseURL: accountBaseURL,
-		ComputeBaseURL: computeBaseURL,
-		UserAgent:      userAgent}
+		AccountBaseURL:     accountBaseURL,
+		ComputeBaseURL:     computeBaseURL,
+		MarketplaceBaseURL: marketplaceBaseURL,
+		UserAgent:          userAgent}
+	c.initServices()
+	return c
+}
+
+// initServices binds the services of the client to c.
+func (c *Client) initServices() {
 	c.Tokens = &TokensService{client: c}
 	c.Organizations = &OrganizationsService{client: c}
 	c.Users = &UsersService{client: c}
@@ -88,7 +166,11 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Servers = &ServersService{client: c}
 	c.Actions = &ActionsService{client: c}
 	c.IPs = &IPsService{client: c}
-	return c
+	c.Tasks = &TasksService{client: c}
+	c.SecurityGroups = &SecurityGroupsService{client: c}
+	c.Bootscripts = &BootscriptsService{client: c}
+	c.Products = &ProductsService{client: c}
+	c.Marketplace = &MarketplaceService{client: c}
 }
 
 // NewRequestAccount creates an API request. A relative URL can be provided in urlStr,
@@ -121,15 +203,39 @@ func (c *Client) NewRequestCompute(method, urlStr string, body interface{}) (*ht
 	return c.newRequest(method, u.String(), body)
 }
 
-// newRequest creates http.Request.
+// NewRequestMarketplace creates an API request. A relative URL can be provided
+// in urlStr, in which case it is resolved relative to the MarketplaceBaseURL
+// of the Client. Relative URLs should always be specified without a
+// preceding slash.  If specified, the value pointed to by body is JSON
+// encoded and included as the request body.
+func (c *Client) NewRequestMarketplace(method, urlStr string, body interface{}) (*http.Request, error) {
+	rel, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.MarketplaceBaseURL.ResolveReference(rel)
+	return c.newRequest(method, u.String(), body)
+}
+
+// newRequest creates http.Request. If body is an io.Reader, it is sent as is
+// with an "application/octet-stream" Content-Type, otherwise it is JSON
+// encoded.
 func (c *Client) newRequest(method, u string, body interface{}) (*http.Request, error) {
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+	var buf io.Reader
+	ct := contentType
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		buf = b
+		ct = "application/octet-stream"
+	default:
+		encoded := new(bytes.Buffer)
+		err := json.NewEncoder(encoded).Encode(body)
 		if err != nil {
 			return nil, err
 		}
+		buf = encoded
 	}
 
 	req, err := http.NewRequest(method, u, buf)
@@ -137,20 +243,31 @@ func (c *Client) newRequest(method, u string, body interface{}) (*http.Request,
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
+	req.Header.Add("Content-Type", ct)
 	if c.UserAgent != "" {
 		req.Header.Add("User-Agent", c.UserAgent)
 	}
-	if c.AuthToken != "" {
-		req.Header.Add("X-Auth-Token", c.AuthToken)
-	}
 	return req, nil
 }
 
-// Do sends an API request and returns the API response.
-func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+// Do sends an API request and returns the API response. The API response is
+// JSON decoded and stored in the value pointed to by v, or returned as an
+// error if an API error has occurred. If v implements the io.Writer
+// interface, the raw response body will be written to v, without attempting
+// to first decode it. The provided ctx must be non-nil; its cancellation and
+// deadline are propagated to the underlying HTTP request.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	req = req.WithContext(ctx)
+
+	resp, err := c.sendAuthenticated(ctx, req)
 	if err != nil {
+		// If we got an error, and the context has been canceled,
+		// the context's error is probably more useful.
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
 		return nil, err
 	}
 	defer func() {
@@ -159,7 +276,14 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 	response := newResponse(resp)
 
-	if v != nil {
+	err = CheckResponse(resp)
+	if err != nil {
+		return response, err
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+	} else if v != nil {
 		err = json.NewDecoder(resp.Body).Decode(v)
 		if err == io.EOF {
 			err = nil // ignore EOF errors caused by empty response body
@@ -170,5 +294,162 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 // newResponse creates a new Response for the provided http.Response.
 func newResponse(r *http.Response) *Response {
-	return &Response{Response: r}
+	response := &Response{Response: r}
+	response.populatePageValues()
+	response.Rate = parseRate(r)
+	return response
+}
+
+// populatePageValues parses the HTTP Link and X-Total-Count response headers
+// and populates the various pagination link values in the Response.
+func (r *Response) populatePageValues() {
+	if count := r.Header.Get("X-Total-Count"); count != "" {
+		r.TotalCount, _ = strconv.Atoi(count)
+	}
+
+	links := r.Header.Get("Link")
+	if links == "" {
+		return
+	}
+	for _, link := range strings.Split(links, ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+
+		// link must at least have href and rel
+		if len(segments) < 2 {
+			continue
+		}
+
+		// ensure href is properly formatted
+		if !strings.HasPrefix(segments[0], "<") || !strings.HasSuffix(segments[0], ">") {
+			continue
+		}
+
+		// try to pull out page parameter
+		u, err := url.Parse(segments[0][1 : len(segments[0])-1])
+		if err != nil {
+			continue
+		}
+		page := u.Query().Get("page")
+		if page == "" {
+			continue
+		}
+
+		for _, segment := range segments[1:] {
+			switch strings.TrimSpace(segment) {
+			case `rel="next"`:
+				r.NextPage, _ = strconv.Atoi(page)
+			case `rel="previous"`, `rel="prev"`:
+				r.PrevPage, _ = strconv.Atoi(page)
+			case `rel="first"`:
+				r.FirstPage, _ = strconv.Atoi(page)
+			case `rel="last"`:
+				r.LastPage, _ = strconv.Atoi(page)
+			}
+		}
+	}
 }
+
+// walkPages calls fetch for every page of a paginated List call, starting
+// from the page set in opt, until there are no more pages or fetch returns
+// false or an error. opt is never modified.
+func walkPages(opt *ListOptions, fetch func(opt *ListOptions) (*Response, bool, error)) error {
+	o := ListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	for {
+		resp, more, err := fetch(&o)
+		if err != nil {
+			return err
+		}
+		if !more || resp.NextPage == 0 || resp.NextPage == o.Page {
+			return nil
+		}
+		o.Page = resp.NextPage
+	}
+}
+
+// An ErrorResponse reports the error caused by an API request.
+//
+// Scaleway API docs: https://developer.scaleway.com/#errors
+type ErrorResponse struct {
+	// HTTP response that caused this error.
+	Response *http.Response
+	// Type of the error, e.g. "unknown_resource" or "invalid_auth".
+	Type string `json:"type"`
+	// Message is the human readable description of the error.
+	Message string `json:"message"`
+	// Fields lists the validation errors per request field.
+	Fields map[string][]string `json:"fields"`
+}
+
+func (r *ErrorResponse) Error() string {
+	if r.Response == nil {
+		return fmt.Sprintf("%v %v", r.Type, r.Message)
+	}
+	if r.Response.Request == nil {
+		return fmt.Sprintf("%d %v %v", r.Response.StatusCode, r.Type, r.Message)
+	}
+	return fmt.Sprintf("%v %v: %d %v %v",
+		r.Response.Request.Method, r.Response.Request.URL,
+		r.Response.StatusCode, r.Type, r.Message)
+}
+
+// CheckResponse checks the API response for errors, and returns them if
+// present. A response is considered an error if it has a status code outside
+// the 200 range. API error responses are expected to have either no response
+// body, or a JSON response body that maps to ErrorResponse. Any other
+// response body will be silently ignored.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+	errorResponse := &ErrorResponse{Response: r}
+	data, err := ioutil.ReadAll(r.Body)
+	if err == nil && data != nil {
+		json.Unmarshal(data, errorResponse)
+	}
+	return errorResponse
+}
+
+// errorResponse returns err as an *ErrorResponse if it is one.
+func errorResponse(err error) (*ErrorResponse, bool) {
+	e, ok := err.(*ErrorResponse)
+	return e, ok && e.Response != nil
+}
+
+// IsNotFound reports whether err is an API error caused by a missing
+// resource.
+func IsNotFound(err error) bool {
+	e, ok := errorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an API error caused by a missing,
+// invalid or expired auth-token.
+func IsUnauthorized(err error) bool {
+	e, ok := errorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusUnauthorized
+}
+
+// IsConflict reports whether err is an API error caused by a conflict with
+// the current state of the resource.
+func IsConflict(err error) bool {
+	e, ok := errorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusConflict
+}
+
+// IsQuotaExceeded reports whether err is an API error caused by exceeding
+// one of the organization quotas.
+func IsQuotaExceeded(err error) bool {
+	e, ok := errorResponse(err)
+	return ok && strings.Contains(e.Type, "quota")
+}
+
+// String is a helper routine that allocates a new string value
+// to store v and returns a pointer to it.
+func String(v string) *string { return &v }
+
+// Bool is a helper routine that allocates a new bool value
+// to store v and returns a pointer to it.
+func Bool(v bool) *bool { return &v }
@@ -1,6 +1,8 @@
 package scaleway
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,7 +10,9 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -33,6 +37,7 @@ func setup() {
 	url, _ := url.Parse(server.URL)
 	client.AccountBaseURL = url
 	client.ComputeBaseURL = url
+	client.MarketplaceBaseURL = url
 }
 
 // teardown closes the test HTTP server.
@@ -73,6 +78,9 @@ func TestNewClient(t *testing.T) {
 	if got, want := c.ComputeBaseURL.String(), defaultComputeBaseURL; got != want {
 		t.Errorf("NewClient ComputeBaseURL is %v, want %v", got, want)
 	}
+	if got, want := c.MarketplaceBaseURL.String(), defaultMarketplaceURL; got != want {
+		t.Errorf("NewClient MarketplaceBaseURL is %v, want %v", got, want)
+	}
 	if got, want := c.UserAgent, userAgent; got != want {
 		t.Errorf("NewClient AccountBaseURL is %v, want %v", got, want)
 	}
@@ -230,10 +238,249 @@ func TestDo(t *testing.T) {
 
 	req, _ := client.NewRequestAccount("GET", "/", nil)
 	body := new(foo)
-	client.Do(req, body)
+	client.Do(context.Background(), req, body)
 
 	want := &foo{"a"}
 	if !reflect.DeepEqual(body, want) {
 		t.Errorf("Response body = %v, want %v", body, want)
 	}
 }
+
+func TestDo_httpError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad Request", 400)
+	})
+
+	req, _ := client.NewRequestAccount("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	if err == nil {
+		t.Error("Expected HTTP 400 error.")
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadRequest,
+		Body: ioutil.NopCloser(strings.NewReader(`{"type":"invalid_request_error",
+			"message":"Validation Error",
+			"fields":{"name":["required key not provided"]}}`)),
+	}
+	err := CheckResponse(res).(*ErrorResponse)
+
+	if err == nil {
+		t.Errorf("Expected error response.")
+	}
+
+	want := &ErrorResponse{
+		Response: res,
+		Type:     "invalid_request_error",
+		Message:  "Validation Error",
+		Fields: map[string][]string{
+			"name": {"required key not provided"},
+		},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Error = %#v, want %#v", err, want)
+	}
+}
+
+// ensure that we properly handle API errors that do not contain a response
+// body
+func TestCheckResponse_noBody(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	err := CheckResponse(res).(*ErrorResponse)
+
+	if err == nil {
+		t.Errorf("Expected error response.")
+	}
+
+	want := &ErrorResponse{
+		Response: res,
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Error = %#v, want %#v", err, want)
+	}
+}
+
+// ensure that API errors of responses without request, or without response,
+// can be formatted
+func TestCheckResponse_noRequest(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"type":"unknown_resource","message":"not found"}`)),
+	}
+	err := CheckResponse(res)
+
+	if got, want := err.Error(), "404 unknown_resource not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got, want := (&ErrorResponse{Type: "unknown_resource", Message: "not found"}).Error(), "unknown_resource not found"; got != want {
+		t.Errorf("Error() without response = %q, want %q", got, want)
+	}
+}
+
+type errorHelperTest struct {
+	status  int
+	errType string
+	fn      func(error) bool
+	want    bool
+}
+
+var errorHelperTests = []errorHelperTest{
+	{http.StatusNotFound, "unknown_resource", IsNotFound, true},
+	{http.StatusBadRequest, "invalid_request_error", IsNotFound, false},
+	{http.StatusUnauthorized, "invalid_auth", IsUnauthorized, true},
+	{http.StatusForbidden, "authorization_required", IsUnauthorized, false},
+	{http.StatusConflict, "conflict", IsConflict, true},
+	{http.StatusForbidden, "quotas_exceeded", IsQuotaExceeded, true},
+	{http.StatusForbidden, "authorization_required", IsQuotaExceeded, false},
+}
+
+func TestErrorHelpers(t *testing.T) {
+	for _, tt := range errorHelperTests {
+		err := &ErrorResponse{
+			Response: &http.Response{StatusCode: tt.status},
+			Type:     tt.errType,
+		}
+		if got := tt.fn(err); got != tt.want {
+			t.Errorf("helper(%d %s) = %v, want %v", tt.status, tt.errType, got, tt.want)
+		}
+		if tt.fn(fmt.Errorf("%d", tt.status)) {
+			t.Errorf("helper(%d) returned true for a non API error", tt.status)
+		}
+	}
+}
+
+func TestDo_canceledContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := client.NewRequestAccount("GET", "/", nil)
+	_, err := client.Do(ctx, req, nil)
+
+	if err != context.Canceled {
+		t.Errorf("Do returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestResponse_populatePageValues(t *testing.T) {
+	r := http.Response{
+		Header: http.Header{
+			"Link": {`</servers?page=1&per_page=2>; rel="first",` +
+				` </servers?page=2&per_page=2>; rel="previous",` +
+				` </servers?page=4&per_page=2>; rel="next",` +
+				` </servers?page=5&per_page=2>; rel="last"`,
+			},
+			"X-Total-Count": {"10"},
+		},
+	}
+
+	response := newResponse(&r)
+	if got, want := response.FirstPage, 1; got != want {
+		t.Errorf("response.FirstPage: %v, want %v", got, want)
+	}
+	if got, want := response.PrevPage, 2; want != got {
+		t.Errorf("response.PrevPage: %v, want %v", got, want)
+	}
+	if got, want := response.NextPage, 4; want != got {
+		t.Errorf("response.NextPage: %v, want %v", got, want)
+	}
+	if got, want := response.LastPage, 5; want != got {
+		t.Errorf("response.LastPage: %v, want %v", got, want)
+	}
+	if got, want := response.TotalCount, 10; want != got {
+		t.Errorf("response.TotalCount: %v, want %v", got, want)
+	}
+}
+
+func TestResponse_populatePageValues_invalid(t *testing.T) {
+	r := http.Response{
+		Header: http.Header{
+			"Link": {`<https://api.scaleway.com/servers?page=1>,` +
+				`<https://api.scaleway.com/servers?page=abc>; rel="first",` +
+				`https://api.scaleway.com/servers?page=2; rel="next",` +
+				`<https://api.scaleway.com/servers>; rel="last"`,
+			},
+		},
+	}
+
+	response := newResponse(&r)
+	if got, want := response.FirstPage, 0; got != want {
+		t.Errorf("response.FirstPage: %v, want %v", got, want)
+	}
+	if got, want := response.PrevPage, 0; got != want {
+		t.Errorf("response.PrevPage: %v, want %v", got, want)
+	}
+	if got, want := response.NextPage, 0; got != want {
+		t.Errorf("response.NextPage: %v, want %v", got, want)
+	}
+	if got, want := response.LastPage, 0; got != want {
+		t.Errorf("response.LastPage: %v, want %v", got, want)
+	}
+}
+
+func TestNewRequest_rawBody(t *testing.T) {
+	c := NewClient(nil)
+
+	req, err := c.newRequest("PATCH", "/foo", strings.NewReader("raw data"))
+	if err != nil {
+		t.Fatalf("newRequest returned error: %v", err)
+	}
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if got, want := string(body), "raw data"; got != want {
+		t.Errorf("newRequest Body is %q, want %q", got, want)
+	}
+	if got, want := req.Header.Get("Content-Type"), "application/octet-stream"; got != want {
+		t.Errorf("newRequest Content-Type is %v, want %v", got, want)
+	}
+}
+
+func TestNtime_MarshalJSON(t *testing.T) {
+	tm, _ := time.Parse(timeLayout, "2014-05-22T12:56:56.984011+00:00")
+	data, err := json.Marshal(struct{ T, Zero Ntime }{T: Ntime(tm)})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if got, want := string(data), `{"T":"2014-05-22T12:56:56.984011Z","Zero":null}`; got != want {
+		t.Errorf("Marshal returned %s, want %s", got, want)
+	}
+
+	var v struct{ T, Zero Ntime }
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if !time.Time(v.T).Equal(tm) || !time.Time(v.Zero).IsZero() {
+		t.Errorf("Unmarshal returned %+v, want %v", v, tm)
+	}
+
+	// the dates of API types round-trip
+	data, err = json.Marshal(&Image{CreationDate: Ntime(tm)})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	image := new(Image)
+	if err := json.Unmarshal(data, image); err != nil {
+		t.Fatalf("Unmarshal of %s returned error: %v", data, err)
+	}
+	if !time.Time(image.CreationDate).Equal(tm) {
+		t.Errorf("Image round-tripped with CreationDate %v, want %v", time.Time(image.CreationDate), tm)
+	}
+}
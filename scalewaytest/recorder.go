@@ -0,0 +1,316 @@
+package scalewaytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the secrets scrubbed from cassettes.
+const Redacted = "REDACTED"
+
+// credentialKeys are the keys of the request bodies holding credentials.
+var credentialKeys = []string{"email", "password", "2FA_token"}
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeRecord sends requests to the API and records them.
+	ModeRecord Mode = iota
+	// ModeReplay serves requests from a cassette, without network access.
+	ModeReplay
+)
+
+// Cassette holds the interactions recorded by a Recorder.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an Interaction.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// A Recorder is an http.RoundTripper recording API sessions to a cassette
+// file, or replaying them from it:
+//
+//	rec, err := scalewaytest.NewRecorder("testdata/servers.json", scalewaytest.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//	client := scaleway.NewClient(&http.Client{Transport: rec})
+//
+// Auth-tokens, token IDs and credentials are replaced with Redacted in the
+// cassette. Replayed requests are matched by method, path, query and body,
+// in recording order, and unmatched requests fail with an error.
+type Recorder struct {
+	// Transport used to send requests in ModeRecord. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	secrets  map[string]bool
+}
+
+// NewRecorder returns a Recorder recording to, or replaying from, the
+// cassette file at path. The cassette must exist in ModeReplay.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		cassette: &Cassette{},
+		secrets:  make(map[string]bool),
+	}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("scalewaytest: invalid cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// Stop ends the session. In ModeRecord, the scrubbed cassette is written to
+// its file. In ModeReplay, an error is returned if some interactions were
+// not replayed.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		var unused []string
+		for i, used := range r.used {
+			if !used {
+				req := r.cassette.Interactions[i].Request
+				unused = append(unused, req.Method+" "+req.URL)
+			}
+		}
+		if len(unused) > 0 {
+			return fmt.Errorf("scalewaytest: %d interactions of %s not replayed: %s",
+				len(unused), r.path, strings.Join(unused, ", "))
+		}
+		return nil
+	}
+
+	for _, i := range r.cassette.Interactions {
+		r.scrub(i)
+	}
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// record sends req and records the interaction.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectSecrets(req, respBody)
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching req.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The token sent is replaced with Redacted in the cassette, and so is it
+	// in the URL of the request.
+	u := *req.URL
+	if token := req.Header.Get("X-Auth-Token"); token != "" {
+		u.Path = strings.Replace(u.Path, token, Redacted, -1)
+	}
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matchRequest(&interaction.Request, req.Method, &u, body) {
+			continue
+		}
+		r.used[i] = true
+
+		rec := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+			StatusCode:    rec.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        rec.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(rec.Body)),
+			ContentLength: int64(len(rec.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("scalewaytest: no interaction of %s matches %s %s %s",
+		r.path, req.Method, req.URL, body)
+}
+
+// matchRequest reports whether the recorded request rec matches a request
+// by method, path, query and body.
+func matchRequest(rec *RecordedRequest, method string, u *url.URL, body []byte) bool {
+	if rec.Method != method {
+		return false
+	}
+	ru, err := url.Parse(rec.URL)
+	if err != nil || ru.Path != u.Path || !reflect.DeepEqual(ru.Query(), u.Query()) {
+		return false
+	}
+	return scrubCredentials(rec.Body) == scrubCredentials(string(body))
+}
+
+// collectSecrets records the secrets of an interaction: the auth-token sent
+// and the IDs of the tokens returned. Credentials are scrubbed by key from
+// request bodies instead, as short passwords could match anything.
+func (r *Recorder) collectSecrets(req *http.Request, respBody []byte) {
+	if token := req.Header.Get("X-Auth-Token"); token != "" {
+		r.secrets[token] = true
+	}
+
+	var tokens struct {
+		Token  *struct{ ID string }
+		Tokens []struct{ ID string }
+	}
+	if json.Unmarshal(respBody, &tokens) == nil {
+		if tokens.Token != nil && tokens.Token.ID != "" {
+			r.secrets[tokens.Token.ID] = true
+		}
+		for _, t := range tokens.Tokens {
+			if t.ID != "" {
+				r.secrets[t.ID] = true
+			}
+		}
+	}
+}
+
+// scrub replaces the secrets of the interaction with Redacted.
+func (r *Recorder) scrub(i *Interaction) {
+	replace := func(s string) string {
+		for secret := range r.secrets {
+			s = strings.Replace(s, secret, Redacted, -1)
+		}
+		return s
+	}
+	scrubHeader := func(h http.Header) {
+		for key, values := range h {
+			for j, v := range values {
+				values[j] = replace(v)
+			}
+			h[key] = values
+		}
+	}
+
+	if i.Request.Header.Get("X-Auth-Token") != "" {
+		i.Request.Header.Set("X-Auth-Token", Redacted)
+	}
+	i.Request.URL = replace(i.Request.URL)
+	i.Request.Body = replace(scrubCredentials(i.Request.Body))
+	scrubHeader(i.Request.Header)
+	i.Response.Body = replace(i.Response.Body)
+	scrubHeader(i.Response.Header)
+}
+
+// scrubCredentials replaces the credentials of a JSON request body with
+// Redacted, normalizing its encoding. Other bodies are returned as is.
+func scrubCredentials(body string) string {
+	var fields map[string]interface{}
+	if body == "" || json.Unmarshal([]byte(body), &fields) != nil {
+		return body
+	}
+	for _, key := range credentialKeys {
+		if _, ok := fields[key]; ok {
+			fields[key] = Redacted
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
@@ -0,0 +1,122 @@
+package scalewaytest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/breakbit/scaleway"
+)
+
+// recorderClient returns a client sending its requests to srv through rec,
+// authenticated with credentials.
+func recorderClient(srv *Server, rec *Recorder) *scaleway.Client {
+	c := scaleway.NewClient(&http.Client{Transport: rec})
+	c.AccountBaseURL = srv.Client().AccountBaseURL
+	c.ComputeBaseURL = srv.Client().ComputeBaseURL
+	c.Authenticator = scaleway.NewTokenSource(c, scaleway.NewCredentials("foo@bar.com", "s3cr3t-passw0rd"))
+	return c
+}
+
+func TestRecorder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Email, srv.Password = "foo@bar.com", "s3cr3t-passw0rd"
+	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "servers.json")
+	session := func(c *scaleway.Client) *scaleway.Server {
+		server, _, err := c.Servers.Create(ctx, &scaleway.ServerRequest{
+			Organization: srv.Organization,
+			Name:         "web",
+			Image:        image.ID,
+		})
+		if err != nil {
+			t.Fatalf("Servers.Create returned error: %v", err)
+		}
+		opt := &scaleway.ServerListOptions{ListOptions: scaleway.ListOptions{PerPage: 10}}
+		if _, _, err := c.Servers.List(ctx, opt); err != nil {
+			t.Fatalf("Servers.List returned error: %v", err)
+		}
+		return server
+	}
+
+	// Record.
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	recorded := session(recorderClient(srv, rec))
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.mu.Lock()
+	secrets := []string{"s3cr3t-passw0rd", "foo@bar.com", srv.tokens[1].ID}
+	srv.mu.Unlock()
+	for _, secret := range secrets {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Cassette contains secret %q", secret)
+		}
+	}
+
+	// Replay, with the fake API closed.
+	srv.Close()
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	c := recorderClient(srv, rec)
+	replayed := session(c)
+	if replayed.ID != recorded.ID {
+		t.Errorf("Replayed server %v, want %v", replayed.ID, recorded.ID)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Stop returned error: %v", err)
+	}
+
+	// Unmatched requests fail.
+	if _, _, err := c.Servers.Get(ctx, recorded.ID); err == nil || !strings.Contains(err.Error(), "no interaction") {
+		t.Errorf("Servers.Get returned %v, want an unmatched request error", err)
+	}
+}
+
+func TestRecorder_unusedInteractions(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	c := scaleway.NewClient(&http.Client{Transport: rec})
+	c.ComputeBaseURL = srv.Client().ComputeBaseURL
+	c.AuthToken = srv.Token
+	if _, _, err := c.Volumes.List(context.Background(), nil); err != nil {
+		t.Fatalf("Volumes.List returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	if err := rec.Stop(); err == nil {
+		t.Error("Stop returned no error with interactions not replayed")
+	}
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("NewRecorder returned no error for a missing cassette")
+	}
+}
@@ -0,0 +1,1025 @@
+// Package scalewaytest provides an in-process fake of the Scaleway compute
+// and account APIs, for testing code built on the scaleway package.
+//
+// The fake is stateful: resources created through a client pointed at it
+// can be listed, updated and deleted afterwards. Server actions go through
+// the same transitions as with the real API (e.g. "stopped", "starting",
+// "running") and are reported by tasks whose progress advances every time
+// the state of the fake is read:
+//
+//	srv := scalewaytest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+//	server, _, err := client.Servers.Create(ctx, &scaleway.ServerRequest{
+//		Organization: srv.Organization,
+//		Name:         "web",
+//		Image:        image.ID,
+//	})
+package scalewaytest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/breakbit/scaleway"
+)
+
+// defaultTaskSteps is the default number of reads a task takes to complete.
+const defaultTaskSteps = 2
+
+// defaultVolumeSize is the size of the root volumes created for images
+// without root volume, 50GB.
+const defaultVolumeSize = 50000000000
+
+// Server is a fake Scaleway API, serving both the account and the compute
+// endpoints. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Token is a valid auth-token, used by the clients returned by Client.
+	Token string
+	// Organization is the ID of the organization of the fake.
+	Organization string
+	// Email and Password are the credentials accepted to create tokens.
+	// Any credentials are accepted when Email is empty.
+	Email    string
+	Password string
+	// TaskSteps is the number of reads a task takes to complete, each read
+	// advancing its progress. Defaults to 2.
+	TaskSteps int
+
+	mu        sync.Mutex
+	seq       int
+	servers   []*scaleway.Server
+	volumes   []*scaleway.Volume
+	snapshots []*scaleway.Snapshot
+	images    []*scaleway.Image
+	ips       []*scaleway.IP
+	tokens    []*scaleway.Token
+	tasks     []*task
+}
+
+// task is a state transition in progress.
+type task struct {
+	*scaleway.Task
+	// hidden tasks aren't exposed by the /tasks endpoints, e.g. the
+	// creation of a snapshot.
+	hidden bool
+	step   int
+	steps  int
+	done   func()
+}
+
+// NewServer starts and returns a new fake Scaleway API. The caller should
+// call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Organization: newID(),
+		TaskSteps:    defaultTaskSteps,
+	}
+	token := &scaleway.Token{
+		ID:                newID(),
+		CreationDate:      now(),
+		InheritsUserPerms: true,
+		Permission:        []string{},
+	}
+	s.Token = token.ID
+	s.tokens = append(s.tokens, token)
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Client returns a scaleway.Client talking to the fake, authenticated with
+// Token.
+func (s *Server) Client() *scaleway.Client {
+	c := scaleway.NewClient(nil)
+	u, _ := url.Parse(s.URL + "/")
+	c.AccountBaseURL = u
+	c.ComputeBaseURL = u
+	c.AuthToken = s.Token
+	c.Organization = s.Organization
+	return c
+}
+
+// AddImage adds a copy of image to the fake, filling its ID, organization
+// and dates if missing, and returns another copy of it.
+func (s *Server) AddImage(image *scaleway.Image) *scaleway.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	image = copyImage(image)
+	if image.ID == "" {
+		image.ID = newID()
+	}
+	if image.Organization == "" {
+		image.Organization = s.Organization
+	}
+	if time.Time(image.CreationDate).IsZero() {
+		image.CreationDate = now()
+		image.ModificationDate = image.CreationDate
+	}
+	s.images = append(s.images, image)
+	return copyImage(image)
+}
+
+// copyImage returns a copy of image not sharing its root volume.
+func copyImage(image *scaleway.Image) *scaleway.Image {
+	c := *image
+	if c.RootVolume != nil {
+		v := *c.RootVolume
+		c.RootVolume = &v
+	}
+	return &c
+}
+
+// Settle completes all the pending tasks, as if they had been polled until
+// their end.
+func (s *Server) Settle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.pendingTasks()) > 0 {
+		s.tick()
+	}
+}
+
+// ServeHTTP serves the requests to the fake API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if !(r.Method == "POST" && r.URL.Path == "/tokens") && !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid_auth", "Authentication error")
+		return
+	}
+	if r.Method == "GET" {
+		s.tick()
+	}
+
+	switch path[0] {
+	case "tokens":
+		s.serveTokens(w, r, path[1:])
+	case "organizations":
+		s.serveOrganizations(w, r, path[1:])
+	case "servers":
+		s.serveServers(w, r, path[1:])
+	case "volumes":
+		s.serveVolumes(w, r, path[1:])
+	case "snapshots":
+		s.serveSnapshots(w, r, path[1:])
+	case "images":
+		s.serveImages(w, r, path[1:])
+	case "ips":
+		s.serveIPs(w, r, path[1:])
+	case "tasks":
+		s.serveTasks(w, r, path[1:])
+	default:
+		writeNotFound(w, r)
+	}
+}
+
+// authorized reports whether r carries a valid, unexpired auth-token.
+func (s *Server) authorized(r *http.Request) bool {
+	token := s.token(r.Header.Get("X-Auth-Token"))
+	if token == nil {
+		return false
+	}
+	expires := time.Time(token.Expires)
+	return expires.IsZero() || time.Now().Before(expires)
+}
+
+// tick advances the pending tasks of one step.
+func (s *Server) tick() {
+	for _, t := range s.pendingTasks() {
+		t.step++
+		t.Progress = strconv.Itoa(t.step * 100 / t.steps)
+		if t.step < t.steps {
+			t.Status = scaleway.TaskStarted
+			continue
+		}
+		t.Status = scaleway.TaskSuccess
+		t.TerminationDate = now()
+		if t.done != nil {
+			t.done()
+		}
+	}
+}
+
+// pendingTasks returns the tasks that are not completed yet.
+func (s *Server) pendingTasks() []*task {
+	var pending []*task
+	for _, t := range s.tasks {
+		if t.Status != scaleway.TaskSuccess {
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
+
+// startTask starts a task completing with done.
+func (s *Server) startTask(description, hrefFrom string, hidden bool, done func()) *task {
+	steps := s.TaskSteps
+	if steps < 1 {
+		steps = defaultTaskSteps
+	}
+	t := &task{
+		Task: &scaleway.Task{
+			ID:          newID(),
+			Description: description,
+			HrefFrom:    hrefFrom,
+			Progress:    "0",
+			StartDate:   now(),
+			Status:      scaleway.TaskPending,
+		},
+		hidden: hidden,
+		steps:  steps,
+		done:   done,
+	}
+	s.tasks = append(s.tasks, t)
+	return t
+}
+
+func (s *Server) serveTokens(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			tokens := s.tokens
+			if userID := r.URL.Query().Get("user_id"); userID != "" {
+				tokens = nil
+				for _, t := range s.tokens {
+					if t.UserID == userID {
+						tokens = append(tokens, t)
+					}
+				}
+			}
+			start, end := paginate(w, r, len(tokens))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"tokens": tokens[start:end]})
+		case "POST":
+			var req struct {
+				scaleway.Credentials
+				Expires      bool     `json:"expires"`
+				Permissions  []string `json:"permissions"`
+				Organization string   `json:"organization"`
+			}
+			if !decode(w, r, &req) {
+				return
+			}
+			if s.Email != "" && (req.Email != s.Email || req.Password != s.Password) {
+				writeError(w, http.StatusUnauthorized, "invalid_auth", "Invalid credentials")
+				return
+			}
+			token := &scaleway.Token{
+				ID:                newID(),
+				CreationDate:      now(),
+				InheritsUserPerms: len(req.Permissions) == 0,
+				Permission:        req.Permissions,
+			}
+			if token.Permission == nil {
+				token.Permission = []string{}
+			}
+			if req.Organization != "" {
+				token.Roles = &scaleway.TokenRoles{Organization: &scaleway.Organization{ID: req.Organization}}
+			}
+			if req.Expires {
+				token.Expires = scaleway.Ntime(time.Time(token.CreationDate).Add(30 * time.Minute))
+			}
+			s.tokens = append(s.tokens, token)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"token": token})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	token := s.token(path[0])
+	if token == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"token": token})
+	case "PATCH":
+		if !time.Time(token.Expires).IsZero() {
+			token.Expires = scaleway.Ntime(time.Time(now()).Add(30 * time.Minute))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"token": token})
+	case "DELETE":
+		for i, t := range s.tokens {
+			if t == token {
+				s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) token(id string) *scaleway.Token {
+	for _, t := range s.tokens {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveOrganizations(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 0 {
+		writeNotFound(w, r)
+		return
+	}
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	org := &scaleway.Organization{ID: s.Organization, Name: "scalewaytest"}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"organizations": []*scaleway.Organization{org}})
+}
+
+func (s *Server) serveServers(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			start, end := paginate(w, r, len(s.servers))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"servers": s.servers[start:end]})
+		case "POST":
+			var req scaleway.ServerRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			s.createServer(w, &req)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	server := s.server(path[0])
+	if server == nil {
+		writeNotFound(w, r)
+		return
+	}
+	if len(path) == 2 && path[1] == "action" {
+		s.serveActions(w, r, server)
+		return
+	}
+	if len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"server": server})
+	case "PUT":
+		var req scaleway.Server
+		if !decode(w, r, &req) {
+			return
+		}
+		s.updateServer(w, server, &req)
+	case "DELETE":
+		if server.State != "stopped" {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "server should be stopped")
+			return
+		}
+		s.deleteServer(server, false)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) createServer(w http.ResponseWriter, req *scaleway.ServerRequest) {
+	image := s.image(req.Image)
+	if image == nil {
+		writeFieldError(w, "image", "unknown image "+req.Image)
+		return
+	}
+
+	server := &scaleway.Server{
+		ID:               newID(),
+		Arch:             image.Arch,
+		BootType:         req.BootType,
+		CommercialType:   req.CommercialType,
+		CreationDate:     now(),
+		DynamicPublicIP:  req.DynamicIPRequired != nil && *req.DynamicIPRequired,
+		EnableIPv6:       req.EnableIPv6,
+		Hostname:         req.Name,
+		Image:            image,
+		Name:             req.Name,
+		Organization:     req.Organization,
+		State:            "stopped",
+		Tags:             req.Tags,
+		Volumes:          make(map[string]*scaleway.Volume),
+		ModificationDate: now(),
+	}
+	if server.CommercialType == "" {
+		server.CommercialType = "VC1S"
+	}
+	if server.BootType == "" {
+		server.BootType = "local"
+	}
+	if server.Tags == nil {
+		server.Tags = []string{}
+	}
+	for slot, id := range req.Volumes {
+		v := s.volume(id)
+		if v == nil {
+			writeFieldError(w, "volumes", "unknown volume "+id)
+			return
+		}
+		server.Volumes[slot] = v
+	}
+
+	root := &scaleway.Volume{
+		ID:           newID(),
+		Name:         image.Name,
+		Organization: req.Organization,
+		Size:         defaultVolumeSize,
+		State:        "available",
+		Type:         "l_ssd",
+	}
+	if image.RootVolume != nil && image.RootVolume.Size != 0 {
+		root.Size = image.RootVolume.Size
+	}
+	s.volumes = append(s.volumes, root)
+	server.Volumes["0"] = root
+
+	s.servers = append(s.servers, server)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"server": server})
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, server *scaleway.Server, req *scaleway.Server) {
+	volumes := make(map[string]*scaleway.Volume, len(req.Volumes))
+	for slot, ref := range req.Volumes {
+		if ref == nil {
+			continue
+		}
+		v := s.volume(ref.ID)
+		if v == nil {
+			writeFieldError(w, "volumes", "unknown volume "+ref.ID)
+			return
+		}
+		volumes[slot] = v
+	}
+
+	server.Name = req.Name
+	server.Tags = req.Tags
+	if server.Tags == nil {
+		server.Tags = []string{}
+	}
+	server.DynamicPublicIP = req.DynamicPublicIP
+	server.BootScript = req.BootScript
+	server.SecurityGroup = req.SecurityGroup
+	server.Volumes = volumes
+	server.ModificationDate = now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": server})
+}
+
+// deleteServer removes server, and its volumes if withVolumes is set.
+func (s *Server) deleteServer(server *scaleway.Server, withVolumes bool) {
+	for i, sv := range s.servers {
+		if sv == server {
+			s.servers = append(s.servers[:i], s.servers[i+1:]...)
+			break
+		}
+	}
+	for _, ip := range s.ips {
+		if ip.Server != nil && ip.Server.ID == server.ID {
+			ip.Server = nil
+		}
+	}
+	if withVolumes {
+		for _, v := range server.Volumes {
+			s.deleteVolume(v)
+		}
+	}
+}
+
+func (s *Server) server(id string) *scaleway.Server {
+	for _, sv := range s.servers {
+		if sv.ID == id {
+			return sv
+		}
+	}
+	return nil
+}
+
+// serverActions lists the actions available in each server state.
+var serverActions = map[string][]string{
+	"stopped": {"poweron"},
+	"running": {"poweroff", "reboot", "terminate"},
+}
+
+func (s *Server) serveActions(w http.ResponseWriter, r *http.Request, server *scaleway.Server) {
+	switch r.Method {
+	case "GET":
+		actions := serverActions[server.State]
+		if actions == nil {
+			actions = []string{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"actions": actions})
+	case "POST":
+		var req scaleway.ActionRequest
+		if !decode(w, r, &req) {
+			return
+		}
+
+		allowed := false
+		for _, a := range serverActions[server.State] {
+			allowed = allowed || a == req.Action
+		}
+		if !allowed {
+			writeError(w, http.StatusBadRequest, "invalid_request_error",
+				fmt.Sprintf("action %q is not allowed in state %q", req.Action, server.State))
+			return
+		}
+
+		var transient string
+		var done func()
+		switch req.Action {
+		case "poweron":
+			transient = "starting"
+			done = func() {
+				server.State = "running"
+				server.PrivateIP = "10.1.0." + strconv.Itoa(s.nextSeq()%254+1)
+			}
+		case "poweroff":
+			transient = "stopping"
+			done = func() {
+				server.State = "stopped"
+				server.PrivateIP = ""
+			}
+		case "reboot":
+			transient = "rebooting"
+			done = func() { server.State = "running" }
+		case "terminate":
+			transient = "stopping"
+			done = func() { s.deleteServer(server, true) }
+		}
+		server.State = transient
+		t := s.startTask("server_"+req.Action, "/servers/"+server.ID+"/action", false, done)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"task": t.Task})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveVolumes(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			start, end := paginate(w, r, len(s.volumes))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"volumes": s.volumes[start:end]})
+		case "POST":
+			var req scaleway.VolumeRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			if req.Size <= 0 {
+				writeFieldError(w, "size", "size must be positive")
+				return
+			}
+			v := &scaleway.Volume{
+				ID:           newID(),
+				Name:         req.Name,
+				Organization: req.Organization,
+				Size:         uint64(req.Size),
+				State:        "available",
+				Type:         req.Type,
+			}
+			s.volumes = append(s.volumes, v)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"volume": v})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	v := s.volume(path[0])
+	if v == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"volume": v})
+	case "DELETE":
+		for _, server := range s.servers {
+			for _, sv := range server.Volumes {
+				if sv == v {
+					writeError(w, http.StatusBadRequest, "invalid_request_error", "volume is attached to a server")
+					return
+				}
+			}
+		}
+		s.deleteVolume(v)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) deleteVolume(v *scaleway.Volume) {
+	for i, sv := range s.volumes {
+		if sv == v {
+			s.volumes = append(s.volumes[:i], s.volumes[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Server) volume(id string) *scaleway.Volume {
+	for _, v := range s.volumes {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveSnapshots(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			start, end := paginate(w, r, len(s.snapshots))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"snapshots": s.snapshots[start:end]})
+		case "POST":
+			var req scaleway.SnapshotRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			v := s.volume(req.Volume)
+			if v == nil {
+				writeFieldError(w, "volume_id", "unknown volume "+req.Volume)
+				return
+			}
+			snapshot := &scaleway.Snapshot{
+				ID:               newID(),
+				Name:             req.Name,
+				CreationDate:     now(),
+				ModificationDate: now(),
+				Organization:     req.Organization,
+				Size:             v.Size,
+				State:            "snapshotting",
+				Type:             v.Type,
+				BaseVolume:       &scaleway.Volume{ID: v.ID, Name: v.Name},
+			}
+			s.snapshots = append(s.snapshots, snapshot)
+			s.startTask("volume_snapshot", "/snapshots/"+snapshot.ID, true, func() {
+				snapshot.State = "available"
+			})
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"snapshot": snapshot})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	snapshot := s.snapshot(path[0])
+	if snapshot == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"snapshot": snapshot})
+	case "PUT":
+		var req scaleway.SnapshotRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.Name != "" {
+			snapshot.Name = req.Name
+		}
+		snapshot.ModificationDate = now()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"snapshot": snapshot})
+	case "DELETE":
+		for i, sn := range s.snapshots {
+			if sn == snapshot {
+				s.snapshots = append(s.snapshots[:i], s.snapshots[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) snapshot(id string) *scaleway.Snapshot {
+	for _, sn := range s.snapshots {
+		if sn.ID == id {
+			return sn
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveImages(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			start, end := paginate(w, r, len(s.images))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"images": s.images[start:end]})
+		case "POST":
+			var req scaleway.ImageRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			snapshot := s.snapshot(req.RootVolume)
+			if snapshot == nil {
+				writeFieldError(w, "root_volume", "unknown snapshot "+req.RootVolume)
+				return
+			}
+			image := &scaleway.Image{
+				ID:               newID(),
+				Arch:             req.Arch,
+				CreationDate:     now(),
+				ModificationDate: now(),
+				Name:             req.Name,
+				Organization:     req.Organization,
+				RootVolume: &scaleway.Volume{
+					ID:   snapshot.ID,
+					Name: snapshot.Name,
+					Size: snapshot.Size,
+					Type: snapshot.Type,
+				},
+			}
+			s.images = append(s.images, image)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"image": image})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	image := s.image(path[0])
+	if image == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"image": image})
+	case "DELETE":
+		for i, im := range s.images {
+			if im == image {
+				s.images = append(s.images[:i], s.images[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) image(id string) *scaleway.Image {
+	for _, im := range s.images {
+		if im.ID == id {
+			return im
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveIPs(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			start, end := paginate(w, r, len(s.ips))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"ips": s.ips[start:end]})
+		case "POST":
+			var req scaleway.IPRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			n := s.nextSeq()
+			ip := &scaleway.IP{
+				ID:           newID(),
+				Address:      fmt.Sprintf("51.15.%d.%d", n/254%256, n%254+1),
+				Organization: req.Organization,
+			}
+			s.ips = append(s.ips, ip)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"ip": ip})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	ip := s.ip(path[0])
+	if ip == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ip": ip})
+	case "PUT":
+		var req scaleway.IPRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if ip.Server != nil {
+			if server := s.server(ip.Server.ID); server != nil {
+				server.PublicIP = ""
+			}
+			ip.Server = nil
+		}
+		if req.Server != "" {
+			server := s.server(req.Server)
+			if server == nil {
+				writeFieldError(w, "server", "unknown server "+req.Server)
+				return
+			}
+			server.PublicIP = ip.Address
+			ip.Server = &scaleway.Server{ID: server.ID, Name: server.Name}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ip": ip})
+	case "DELETE":
+		if ip.Server != nil {
+			if server := s.server(ip.Server.ID); server != nil {
+				server.PublicIP = ""
+			}
+		}
+		for i, sip := range s.ips {
+			if sip == ip {
+				s.ips = append(s.ips[:i], s.ips[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) ip(id string) *scaleway.IP {
+	for _, ip := range s.ips {
+		if ip.ID == id {
+			return ip
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveTasks(w http.ResponseWriter, r *http.Request, path []string) {
+	var tasks []*scaleway.Task
+	for _, t := range s.tasks {
+		if !t.hidden {
+			tasks = append(tasks, t.Task)
+		}
+	}
+
+	if len(path) == 0 {
+		if r.Method != "GET" {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		start, end := paginate(w, r, len(tasks))
+		writeJSON(w, http.StatusOK, map[string]interface{}{"tasks": tasks[start:end]})
+		return
+	}
+
+	var t *task
+	for _, st := range s.tasks {
+		if st.ID == path[0] && !st.hidden {
+			t = st
+		}
+	}
+	if t == nil || len(path) > 1 {
+		writeNotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"task": t.Task})
+	case "DELETE":
+		for i, st := range s.tasks {
+			if st == t {
+				s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// nextSeq returns the next value of the sequence of the fake.
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+// paginate returns the bounds of the requested page of a collection of n
+// items, and sets the pagination headers of w.
+func paginate(w http.ResponseWriter, r *http.Request, n int) (start, end int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(n))
+
+	q := r.URL.Query()
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 {
+		return 0, n
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	last := (n + perPage - 1) / perPage
+	if last < 1 {
+		last = 1
+	}
+
+	link := func(page int, rel string) string {
+		q.Set("page", strconv.Itoa(page))
+		u := *r.URL
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+	links := []string{link(1, "first")}
+	if page > 1 {
+		links = append(links, link(page-1, "previous"))
+	}
+	if page < last {
+		links = append(links, link(page+1, "next"))
+	}
+	links = append(links, link(last, "last"))
+	w.Header().Set("Link", strings.Join(links, ", "))
+
+	start = (page - 1) * perPage
+	if start > n {
+		start = n
+	}
+	end = start + perPage
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// decode decodes the JSON body of r into v, replying with a 400 Bad Request
+// on failure.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, typ, message string) {
+	writeJSON(w, status, map[string]string{"type": typ, "message": message})
+}
+
+func writeFieldError(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"type":    "invalid_request_error",
+		"message": "Validation Error",
+		"fields":  map[string][]string{field: {message}},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "unknown_resource", r.URL.Path+" not found")
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "invalid_request_error", r.Method+" is not allowed on "+r.URL.Path)
+}
+
+// now returns the current time, with the precision of the API.
+func now() scaleway.Ntime {
+	return scaleway.Ntime(time.Now().UTC().Truncate(time.Microsecond))
+}
+
+// newID returns a random UUID.
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,252 @@
+package scalewaytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/breakbit/scaleway"
+)
+
+// waitOptions polls the fake without delay.
+var waitOptions = &scaleway.WaitOptions{PollInterval: time.Millisecond, Timeout: 5 * time.Second}
+
+func TestServer_serverLifecycle(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+
+	server, _, err := client.Servers.Create(ctx, &scaleway.ServerRequest{
+		Organization: srv.Organization,
+		Name:         "web",
+		Image:        image.ID,
+		Tags:         []string{"prod"},
+	})
+	if err != nil {
+		t.Fatalf("Servers.Create returned error: %v", err)
+	}
+	if server.State != "stopped" || server.Volumes["0"] == nil {
+		t.Fatalf("Servers.Create returned %+v, want a stopped server with a root volume", server)
+	}
+
+	task, _, err := client.Actions.Exec(ctx, server.ID, &scaleway.ActionRequest{Action: "poweron"})
+	if err != nil {
+		t.Fatalf("Actions.Exec returned error: %v", err)
+	}
+	if got, want := task.ServerID(), server.ID; got != want {
+		t.Errorf("Actions.Exec returned a task of server %v, want %v", got, want)
+	}
+
+	var progress []string
+	opt := *waitOptions
+	opt.Progress = func(s scaleway.WaitStatus) { progress = append(progress, s.Progress) }
+	if _, err := client.WaitForTask(ctx, task.ID, &opt); err != nil {
+		t.Fatalf("WaitForTask returned error: %v", err)
+	}
+	if len(progress) != 2 || progress[0] != "50" || progress[1] != "100" {
+		t.Errorf("WaitForTask reported progress %v, want [50 100]", progress)
+	}
+
+	server, _, err = client.Servers.Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if server.State != "running" || server.PrivateIP == "" {
+		t.Errorf("Servers.Get returned %+v, want a running server with a private IP", server)
+	}
+
+	if _, err := client.Servers.Delete(ctx, server.ID); err == nil {
+		t.Error("Servers.Delete of a running server returned no error")
+	}
+
+	if _, _, err := client.Actions.Exec(ctx, server.ID, &scaleway.ActionRequest{Action: "terminate"}); err != nil {
+		t.Fatalf("Actions.Exec returned error: %v", err)
+	}
+	srv.Settle()
+
+	if _, _, err := client.Servers.Get(ctx, server.ID); !scaleway.IsNotFound(err) {
+		t.Errorf("Servers.Get of a terminated server returned %v, want a 404 error", err)
+	}
+	if _, _, err := client.Volumes.Get(ctx, server.Volumes["0"].ID); !scaleway.IsNotFound(err) {
+		t.Errorf("Volumes.Get of a terminated server volume returned %v, want a 404 error", err)
+	}
+}
+
+func TestServer_AddImage(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+	image.Name = "changed"
+
+	got, _, err := srv.Client().Images.Get(context.Background(), image.ID)
+	if err != nil {
+		t.Fatalf("Images.Get returned error: %v", err)
+	}
+	if got.Name != "Ubuntu Xenial" {
+		t.Errorf("Images.Get returned name %q, want the name of the added image", got.Name)
+	}
+}
+
+func TestServer_serverUpdate(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+
+	server, _, err := client.Servers.Create(ctx, &scaleway.ServerRequest{
+		Organization: srv.Organization,
+		Name:         "web",
+		Image:        image.ID,
+	})
+	if err != nil {
+		t.Fatalf("Servers.Create returned error: %v", err)
+	}
+
+	server, _, err = client.Servers.Update(ctx, server.ID, &scaleway.ServerUpdateRequest{
+		Name: scaleway.String("api"),
+		Tags: &[]string{"staging"},
+	})
+	if err != nil {
+		t.Fatalf("Servers.Update returned error: %v", err)
+	}
+	if server.Name != "api" || len(server.Tags) != 1 || server.Volumes["0"] == nil {
+		t.Errorf("Servers.Update returned %+v", server)
+	}
+
+	_, _, err = client.Servers.Create(ctx, &scaleway.ServerRequest{Name: "web", Image: "missing"})
+	if err == nil {
+		t.Error("Servers.Create with an unknown image returned no error")
+	}
+}
+
+func TestServer_storage(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+
+	volume, _, err := client.Volumes.Create(ctx, &scaleway.VolumeRequest{
+		Name:         "data",
+		Organization: srv.Organization,
+		Type:         "l_ssd",
+		Size:         10000000000,
+	})
+	if err != nil {
+		t.Fatalf("Volumes.Create returned error: %v", err)
+	}
+
+	snapshot, _, err := client.Snapshots.Create(ctx, &scaleway.SnapshotRequest{
+		Name:         "data-snapshot",
+		Organization: srv.Organization,
+		Volume:       volume.ID,
+	})
+	if err != nil {
+		t.Fatalf("Snapshots.Create returned error: %v", err)
+	}
+	if snapshot.State != "snapshotting" {
+		t.Errorf("Snapshots.Create returned state %q, want snapshotting", snapshot.State)
+	}
+	if _, err := client.WaitForSnapshotState(ctx, snapshot.ID, "available", waitOptions); err != nil {
+		t.Fatalf("WaitForSnapshotState returned error: %v", err)
+	}
+
+	image, _, err := client.Images.Create(ctx, &scaleway.ImageRequest{
+		Organization: srv.Organization,
+		Name:         "data-image",
+		Arch:         "x86_64",
+		RootVolume:   snapshot.ID,
+	})
+	if err != nil {
+		t.Fatalf("Images.Create returned error: %v", err)
+	}
+	if got, want := image.RootVolume.Size, volume.Size; got != want {
+		t.Errorf("Images.Create returned a root volume of %d bytes, want %d", got, want)
+	}
+
+	tasks, err := client.Tasks.ListAll(ctx, nil)
+	if err != nil {
+		t.Fatalf("Tasks.ListAll returned error: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("Tasks.ListAll returned %d tasks, want none", len(tasks))
+	}
+}
+
+func TestServer_ips(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	image := srv.AddImage(&scaleway.Image{Name: "Ubuntu Xenial", Arch: "x86_64"})
+
+	server, _, err := client.Servers.Create(ctx, &scaleway.ServerRequest{
+		Organization: srv.Organization,
+		Name:         "web",
+		Image:        image.ID,
+	})
+	if err != nil {
+		t.Fatalf("Servers.Create returned error: %v", err)
+	}
+
+	ip, _, err := client.IPs.Create(ctx, &scaleway.IPRequest{Organization: srv.Organization})
+	if err != nil {
+		t.Fatalf("IPs.Create returned error: %v", err)
+	}
+	ip, _, err = client.IPs.Attach(ctx, &scaleway.IPRequest{
+		Organization: srv.Organization,
+		ID:           ip.ID,
+		Server:       server.ID,
+	}, ip.ID)
+	if err != nil {
+		t.Fatalf("IPs.Attach returned error: %v", err)
+	}
+	if ip.Server == nil || ip.Server.ID != server.ID {
+		t.Errorf("IPs.Attach returned %+v, want an IP attached to %v", ip, server.ID)
+	}
+
+	server, _, err = client.Servers.Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if got, want := server.PublicIP, ip.Address; got != want {
+		t.Errorf("Servers.Get returned public IP %v, want %v", got, want)
+	}
+}
+
+func TestServer_tokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Email, srv.Password = "foo@bar.com", "foobar"
+
+	ctx := context.Background()
+	client := srv.Client()
+	client.AuthToken = ""
+
+	if _, _, err := client.Servers.List(ctx, nil); !scaleway.IsUnauthorized(err) {
+		t.Errorf("Servers.List without token returned %v, want a 401 error", err)
+	}
+	if _, _, err := client.Tokens.Create(ctx, scaleway.NewCredentials("foo@bar.com", "wrong"), true); !scaleway.IsUnauthorized(err) {
+		t.Errorf("Tokens.Create with wrong credentials returned %v, want a 401 error", err)
+	}
+
+	client.Authenticator = scaleway.NewTokenSource(client, scaleway.NewCredentials("foo@bar.com", "foobar"))
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+
+	tokens, err := client.Tokens.ListAll(ctx, &scaleway.TokenListOptions{ListOptions: scaleway.ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Fatalf("Tokens.ListAll returned error: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("Tokens.ListAll returned %d tokens, want 2", len(tokens))
+	}
+}
@@ -0,0 +1,282 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+)
+
+// SecurityGroupsService handles communication with the security groups
+// related methods of the Scaleway API.
+//
+// Scaleway API docs: https://developer.scaleway.com/#security-groups
+type SecurityGroupsService struct {
+	client *Client
+}
+
+// SecurityGroup represents a Scaleway security group.
+type SecurityGroup struct {
+	ID                    string    `json:"id,omitempty"`
+	Name                  string    `json:"name,omitempty"`
+	Description           string    `json:"description,omitempty"`
+	Organization          string    `json:"organization,omitempty"`
+	OrganizationDefault   bool      `json:"organization_default,omitempty"`
+	EnableDefaultSecurity bool      `json:"enable_default_security,omitempty"`
+	InboundDefaultPolicy  string    `json:"inbound_default_policy,omitempty"`
+	OutboundDefaultPolicy string    `json:"outbound_default_policy,omitempty"`
+	Servers               []*Server `json:"servers,omitempty"`
+}
+
+// SecurityGroupRequest represents a request to create or update a security
+// group.
+type SecurityGroupRequest struct {
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	Organization          string `json:"organization"`
+	OrganizationDefault   bool   `json:"organization_default"`
+	EnableDefaultSecurity bool   `json:"enable_default_security"`
+	InboundDefaultPolicy  string `json:"inbound_default_policy,omitempty"`
+	OutboundDefaultPolicy string `json:"outbound_default_policy,omitempty"`
+}
+
+// SecurityGroupRule represents a rule of a Scaleway security group.
+type SecurityGroupRule struct {
+	ID           string `json:"id,omitempty"`
+	Protocol     string `json:"protocol,omitempty"`
+	Direction    string `json:"direction,omitempty"`
+	Action       string `json:"action,omitempty"`
+	IPRange      string `json:"ip_range,omitempty"`
+	DestPortFrom int    `json:"dest_port_from,omitempty"`
+	DestPortTo   int    `json:"dest_port_to,omitempty"`
+	Position     int    `json:"position,omitempty"`
+	Editable     bool   `json:"editable,omitempty"`
+}
+
+// SecurityGroupRuleRequest represents a request to create or update a
+// security group rule.
+type SecurityGroupRuleRequest struct {
+	Protocol     string `json:"protocol"`
+	Direction    string `json:"direction"`
+	Action       string `json:"action"`
+	IPRange      string `json:"ip_range"`
+	DestPortFrom int    `json:"dest_port_from,omitempty"`
+	DestPortTo   int    `json:"dest_port_to,omitempty"`
+	Position     int    `json:"position,omitempty"`
+}
+
+// securityGroupResponse represents a Scaleway security group response.
+type securityGroupResponse struct {
+	SecurityGroup *SecurityGroup `json:"security_group"`
+}
+
+// securityGroupListResponse represents a Scaleway security groups list
+// response.
+type securityGroupListResponse struct {
+	SecurityGroups []*SecurityGroup `json:"security_groups"`
+}
+
+// securityGroupRuleResponse represents a Scaleway security group rule
+// response.
+type securityGroupRuleResponse struct {
+	Rule *SecurityGroupRule `json:"rule"`
+}
+
+// securityGroupRuleListResponse represents a Scaleway security group rules
+// list response.
+type securityGroupRuleListResponse struct {
+	Rules []*SecurityGroupRule `json:"rules"`
+}
+
+// Create creates a security group.
+func (s *SecurityGroupsService) Create(ctx context.Context, sr *SecurityGroupRequest) (*SecurityGroup, *Response, error) {
+	u := fmt.Sprintf("/security_groups")
+	req, err := s.client.NewRequestCompute("POST", u, sr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(securityGroupResponse)
+	resp, err := s.client.Do(ctx, req, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group.SecurityGroup, resp, nil
+}
+
+// List returns a list of all security groups associate to your account.
+func (s *SecurityGroupsService) List(ctx context.Context, opt *ListOptions) ([]*SecurityGroup, *Response, error) {
+	return s.listSecurityGroups(ctx, opt)
+}
+
+func (s *SecurityGroupsService) listSecurityGroups(ctx context.Context, opt *ListOptions) ([]*SecurityGroup, *Response, error) {
+	u, err := addOptions("/security_groups", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := new(securityGroupListResponse)
+	resp, err := s.client.Do(ctx, req, groups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return groups.SecurityGroups, resp, nil
+}
+
+// ListPages calls fn for every page of security groups, starting from the
+// page set in opt, until there are no more pages or fn returns false. Each
+// page is only fetched once the previous one has been handled.
+func (s *SecurityGroupsService) ListPages(ctx context.Context, opt *ListOptions, fn func([]*SecurityGroup) bool) error {
+	return walkPages(opt, func(opt *ListOptions) (*Response, bool, error) {
+		groups, resp, err := s.List(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(groups), nil
+	})
+}
+
+// ListAll returns the security groups of every page, starting from the page
+// set in opt.
+func (s *SecurityGroupsService) ListAll(ctx context.Context, opt *ListOptions) ([]*SecurityGroup, error) {
+	var all []*SecurityGroup
+	err := s.ListPages(ctx, opt, func(groups []*SecurityGroup) bool {
+		all = append(all, groups...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Get returns info for a specific security group.
+func (s *SecurityGroupsService) Get(ctx context.Context, id string) (*SecurityGroup, *Response, error) {
+	u := fmt.Sprintf("/security_groups/%s", id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(securityGroupResponse)
+	resp, err := s.client.Do(ctx, req, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group.SecurityGroup, resp, nil
+}
+
+// Update updates the details about a security group.
+func (s *SecurityGroupsService) Update(ctx context.Context, id string, sr *SecurityGroupRequest) (*SecurityGroup, *Response, error) {
+	u := fmt.Sprintf("/security_groups/%s", id)
+	req, err := s.client.NewRequestCompute("PUT", u, sr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(securityGroupResponse)
+	resp, err := s.client.Do(ctx, req, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group.SecurityGroup, resp, nil
+}
+
+// Delete deletes a security group.
+func (s *SecurityGroupsService) Delete(ctx context.Context, id string) (*Response, error) {
+	u := fmt.Sprintf("/security_groups/%s", id)
+	req, err := s.client.NewRequestCompute("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateRule creates a rule in the security group groupID.
+func (s *SecurityGroupsService) CreateRule(ctx context.Context, groupID string, rr *SecurityGroupRuleRequest) (*SecurityGroupRule, *Response, error) {
+	u := fmt.Sprintf("/security_groups/%s/rules", groupID)
+	req, err := s.client.NewRequestCompute("POST", u, rr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := new(securityGroupRuleResponse)
+	resp, err := s.client.Do(ctx, req, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rule.Rule, resp, nil
+}
+
+// ListRules returns the rules of the security group groupID.
+func (s *SecurityGroupsService) ListRules(ctx context.Context, groupID string, opt *ListOptions) ([]*SecurityGroupRule, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("/security_groups/%s/rules", groupID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules := new(securityGroupRuleListResponse)
+	resp, err := s.client.Do(ctx, req, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rules.Rules, resp, nil
+}
+
+// GetRule returns info for a specific rule of the security group groupID.
+func (s *SecurityGroupsService) GetRule(ctx context.Context, groupID, id string) (*SecurityGroupRule, *Response, error) {
+	u := fmt.Sprintf("/security_groups/%s/rules/%s", groupID, id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := new(securityGroupRuleResponse)
+	resp, err := s.client.Do(ctx, req, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rule.Rule, resp, nil
+}
+
+// UpdateRule updates a rule of the security group groupID.
+func (s *SecurityGroupsService) UpdateRule(ctx context.Context, groupID, id string, rr *SecurityGroupRuleRequest) (*SecurityGroupRule, *Response, error) {
+	u := fmt.Sprintf("/security_groups/%s/rules/%s", groupID, id)
+	req, err := s.client.NewRequestCompute("PUT", u, rr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := new(securityGroupRuleResponse)
+	resp, err := s.client.Do(ctx, req, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rule.Rule, resp, nil
+}
+
+// DeleteRule deletes a rule of the security group groupID.
+func (s *SecurityGroupsService) DeleteRule(ctx context.Context, groupID, id string) (*Response, error) {
+	u := fmt.Sprintf("/security_groups/%s/rules/%s", groupID, id)
+	req, err := s.client.NewRequestCompute("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
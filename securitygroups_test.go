@@ -0,0 +1,281 @@
+package scaleway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testSecurityGroup = &SecurityGroup{
+	ID:                    "1d3f0e36-2d2c-4c36-8d6e-c3d3d5b9a1c4",
+	Name:                  "Default security group",
+	Description:           "Default security group",
+	Organization:          "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+	OrganizationDefault:   true,
+	EnableDefaultSecurity: true,
+	InboundDefaultPolicy:  "accept",
+	OutboundDefaultPolicy: "accept",
+}
+
+var testSecurityGroupRule = &SecurityGroupRule{
+	ID:           "a4e9d6c3-7b0e-4b8f-8e7b-3f6f7c1c2d5e",
+	Protocol:     "TCP",
+	Direction:    "outbound",
+	Action:       "drop",
+	IPRange:      "0.0.0.0/0",
+	DestPortFrom: 25,
+	Position:     1,
+	Editable:     true,
+}
+
+func TestSecurityGroupsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	inBody := &SecurityGroupRequest{
+		Name:                  "Default security group",
+		Description:           "Default security group",
+		Organization:          "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		OrganizationDefault:   true,
+		EnableDefaultSecurity: true,
+		InboundDefaultPolicy:  "accept",
+		OutboundDefaultPolicy: "accept",
+	}
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_get.json"))
+
+	mux.HandleFunc("/security_groups", func(w http.ResponseWriter, r *http.Request) {
+		v := new(SecurityGroupRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		if !reflect.DeepEqual(v, inBody) {
+			t.Errorf("Request body = %+v, want %+v", v, inBody)
+		}
+		w.Header().Add("Content-Type", contentType)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(data))
+	})
+
+	group, _, err := client.SecurityGroups.Create(context.Background(), inBody)
+	if err != nil {
+		t.Errorf("SecurityGroups.Create returned error: %v", err)
+	}
+
+	want := *testSecurityGroup
+	want.Servers = []*Server{{ID: "741db378-6b87-46d4-a8c5-4e46a09ab1f8", Name: "my_server"}}
+	if !reflect.DeepEqual(group, &want) {
+		t.Errorf("SecurityGroups.Create returned %+v\n, want %+v", group, &want)
+	}
+}
+
+func TestSecurityGroupsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_list.json"))
+
+	mux.HandleFunc("/security_groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	groups, _, err := client.SecurityGroups.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("SecurityGroups.List returned error: %v", err)
+	}
+
+	want := *testSecurityGroup
+	want.Servers = []*Server{}
+	if !reflect.DeepEqual(groups, []*SecurityGroup{&want}) {
+		t.Errorf("SecurityGroups.List returned %+v\n, want %+v", groups, []*SecurityGroup{&want})
+	}
+}
+
+func TestSecurityGroupsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	inBody := &SecurityGroupRequest{
+		Name:                 "Default security group",
+		Organization:         "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		InboundDefaultPolicy: "drop",
+	}
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s", testSecurityGroup.ID), func(w http.ResponseWriter, r *http.Request) {
+		v := new(SecurityGroupRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "PUT")
+		if !reflect.DeepEqual(v, inBody) {
+			t.Errorf("Request body = %+v, want %+v", v, inBody)
+		}
+		fmt.Fprintf(w, `{"security_group": {"id": %q, "inbound_default_policy": "drop"}}`, testSecurityGroup.ID)
+	})
+
+	group, _, err := client.SecurityGroups.Update(context.Background(), testSecurityGroup.ID, inBody)
+	if err != nil {
+		t.Errorf("SecurityGroups.Update returned error: %v", err)
+	}
+	if want := (&SecurityGroup{ID: testSecurityGroup.ID, InboundDefaultPolicy: "drop"}); !reflect.DeepEqual(group, want) {
+		t.Errorf("SecurityGroups.Update returned %+v\n, want %+v", group, want)
+	}
+}
+
+func TestSecurityGroupsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s", testSecurityGroup.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.SecurityGroups.Delete(context.Background(), testSecurityGroup.ID)
+	if err != nil {
+		t.Errorf("SecurityGroups.Delete returned error: %v", err)
+	}
+}
+
+func TestSecurityGroupsService_CreateRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	inBody := &SecurityGroupRuleRequest{
+		Protocol:     "TCP",
+		Direction:    "outbound",
+		Action:       "drop",
+		IPRange:      "0.0.0.0/0",
+		DestPortFrom: 25,
+	}
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_rules_get.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s/rules", testSecurityGroup.ID), func(w http.ResponseWriter, r *http.Request) {
+		v := new(SecurityGroupRuleRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "POST")
+		if !reflect.DeepEqual(v, inBody) {
+			t.Errorf("Request body = %+v, want %+v", v, inBody)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(data))
+	})
+
+	rule, _, err := client.SecurityGroups.CreateRule(context.Background(), testSecurityGroup.ID, inBody)
+	if err != nil {
+		t.Errorf("SecurityGroups.CreateRule returned error: %v", err)
+	}
+	if !reflect.DeepEqual(rule, testSecurityGroupRule) {
+		t.Errorf("SecurityGroups.CreateRule returned %+v\n, want %+v", rule, testSecurityGroupRule)
+	}
+}
+
+func TestSecurityGroupsService_ListRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_rules_list.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s/rules", testSecurityGroup.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	rules, _, err := client.SecurityGroups.ListRules(context.Background(), testSecurityGroup.ID, nil)
+	if err != nil {
+		t.Errorf("SecurityGroups.ListRules returned error: %v", err)
+	}
+	if want := []*SecurityGroupRule{testSecurityGroupRule}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("SecurityGroups.ListRules returned %+v\n, want %+v", rules, want)
+	}
+}
+
+func TestSecurityGroupsService_GetRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_rules_get.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s/rules/%s", testSecurityGroup.ID, testSecurityGroupRule.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	rule, _, err := client.SecurityGroups.GetRule(context.Background(), testSecurityGroup.ID, testSecurityGroupRule.ID)
+	if err != nil {
+		t.Errorf("SecurityGroups.GetRule returned error: %v", err)
+	}
+	if !reflect.DeepEqual(rule, testSecurityGroupRule) {
+		t.Errorf("SecurityGroups.GetRule returned %+v\n, want %+v", rule, testSecurityGroupRule)
+	}
+}
+
+func TestSecurityGroupsService_UpdateRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	inBody := &SecurityGroupRuleRequest{
+		Protocol:  "TCP",
+		Direction: "outbound",
+		Action:    "drop",
+		IPRange:   "10.0.0.0/8",
+	}
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "security_groups_rules_get.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s/rules/%s", testSecurityGroup.ID, testSecurityGroupRule.ID), func(w http.ResponseWriter, r *http.Request) {
+		v := new(SecurityGroupRuleRequest)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "PUT")
+		if !reflect.DeepEqual(v, inBody) {
+			t.Errorf("Request body = %+v, want %+v", v, inBody)
+		}
+		fmt.Fprint(w, string(data))
+	})
+
+	_, _, err := client.SecurityGroups.UpdateRule(context.Background(), testSecurityGroup.ID, testSecurityGroupRule.ID, inBody)
+	if err != nil {
+		t.Errorf("SecurityGroups.UpdateRule returned error: %v", err)
+	}
+}
+
+func TestSecurityGroupsService_DeleteRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc(fmt.Sprintf("/security_groups/%s/rules/%s", testSecurityGroup.ID, testSecurityGroupRule.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.SecurityGroups.DeleteRule(context.Background(), testSecurityGroup.ID, testSecurityGroupRule.ID)
+	if err != nil {
+		t.Errorf("SecurityGroups.DeleteRule returned error: %v", err)
+	}
+}
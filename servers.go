@@ -1,6 +1,15 @@
 package scaleway
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
 
 // ServersService handles communication with the servers related
 // methods of the Scaleway API.
@@ -12,17 +21,68 @@ type ServersService struct {
 
 // Server represents a Scaleway server.
 type Server struct {
-	ID              string             `json:"id,omitempty"`
-	BootScript      string             `json:"bootscript,omitempty"`
-	DynamicPublicIP bool               `json:"dynamic_public_ip,omitempty"`
-	Image           *Image             `json:"image,omitempty"`
-	Name            string             `json:"name,omitempty"`
-	Organization    string             `json:"organization,omitempty"`
-	PrivateIP       string             `json:"private_ip,omitempty"`
-	PublicIP        string             `json:"public_ip,omitempty"`
-	State           string             `json:"state,omitempty"`
-	Tags            []string           `json:"tags,omitempty"`
-	Volumes         map[string]*Volume `json:"volumes,omitempty"`
+	ID               string             `json:"id,omitempty"`
+	Arch             string             `json:"arch,omitempty"`
+	BootScript       *Bootscript        `json:"bootscript,omitempty"`
+	BootType         string             `json:"boot_type,omitempty"`
+	CommercialType   string             `json:"commercial_type,omitempty"`
+	CreationDate     Ntime              `json:"creation_date,omitempty"`
+	DynamicPublicIP  bool               `json:"dynamic_public_ip,omitempty"`
+	EnableIPv6       bool               `json:"enable_ipv6,omitempty"`
+	Hostname         string             `json:"hostname,omitempty"`
+	Image            *Image             `json:"image,omitempty"`
+	IPv6             *ServerIPv6        `json:"ipv6,omitempty"`
+	Location         *ServerLocation    `json:"location,omitempty"`
+	ModificationDate Ntime              `json:"modification_date,omitempty"`
+	Name             string             `json:"name,omitempty"`
+	Organization     string             `json:"organization,omitempty"`
+	PrivateIP        string             `json:"private_ip,omitempty"`
+	PublicIP         string             `json:"public_ip,omitempty"`
+	SecurityGroup    *SecurityGroup     `json:"security_group,omitempty"`
+	State            string             `json:"state,omitempty"`
+	Tags             []string           `json:"tags,omitempty"`
+	Volumes          map[string]*Volume `json:"volumes,omitempty"`
+	// Region the server lives in. Only set by ListAllRegions.
+	Region Region `json:"-"`
+}
+
+// ServerIPv6 represents the IPv6 configuration of a Scaleway server.
+type ServerIPv6 struct {
+	Address string `json:"address,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+	Netmask string `json:"netmask,omitempty"`
+}
+
+// ServerLocation represents the physical location of a Scaleway server.
+type ServerLocation struct {
+	ClusterID    string `json:"cluster_id,omitempty"`
+	HypervisorID string `json:"hypervisor_id,omitempty"`
+	NodeID       string `json:"node_id,omitempty"`
+	PlatformID   string `json:"platform_id,omitempty"`
+	ZoneID       string `json:"zone_id,omitempty"`
+}
+
+// ExtraVolumes returns the volumes of the server other than the root
+// volume, ordered by slot.
+func (s *Server) ExtraVolumes() []*Volume {
+	slots := make([]string, 0, len(s.Volumes))
+	for slot := range s.Volumes {
+		if slot != "0" {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		if len(slots[i]) != len(slots[j]) {
+			return len(slots[i]) < len(slots[j])
+		}
+		return slots[i] < slots[j]
+	})
+
+	volumes := make([]*Volume, 0, len(slots))
+	for _, slot := range slots {
+		volumes = append(volumes, s.Volumes[slot])
+	}
+	return volumes
 }
 
 // ServerRequest represents a request to create a server.
@@ -31,6 +91,100 @@ type ServerRequest struct {
 	Name         string   `json:"name"`
 	Image        string   `json:"image"`
 	Tags         []string `json:"tags"`
+	// CommercialType is the type of the server, e.g. "VC1S" or "C2M".
+	CommercialType string `json:"commercial_type,omitempty"`
+	// Volumes maps slots to the IDs of the extra volumes to attach, the
+	// root volume ("0") being created from Image.
+	Volumes map[string]string `json:"volumes,omitempty"`
+	// BootScript is the ID of the bootscript to boot the server with.
+	BootScript string `json:"bootscript,omitempty"`
+	// BootType is the boot method of the server, e.g. "local" or
+	// "bootscript".
+	BootType string `json:"boot_type,omitempty"`
+	// DynamicIPRequired requests a dynamic public IP when true. The API
+	// default applies when nil.
+	DynamicIPRequired *bool `json:"dynamic_ip_required,omitempty"`
+	// PublicIP is the ID of a reserved IP to attach to the server.
+	PublicIP string `json:"public_ip,omitempty"`
+	// EnableIPv6 requests an IPv6 address for the server.
+	EnableIPv6 bool `json:"enable_ipv6,omitempty"`
+	// SecurityGroup is the ID of the security group of the server. The
+	// organization default security group is used when empty.
+	SecurityGroup string `json:"security_group,omitempty"`
+}
+
+// ServerListOptions specifies the optional parameters to the
+// ServersService.List method. Filters are sent as query parameters and
+// also applied to the returned servers, in case the API ignores them.
+type ServerListOptions struct {
+	// Name filters servers whose name contains the given string.
+	Name string `url:"name,omitempty"`
+	// State filters servers in the given state, e.g. "running".
+	State string `url:"state,omitempty"`
+	// Tags filters servers having all the given tags.
+	Tags []string `url:"tags,omitempty"`
+	// Organization filters servers owned by the given organization.
+	Organization string `url:"organization,omitempty"`
+	// CommercialType filters servers of the given type, e.g. "VC1S".
+	CommercialType string `url:"commercial_type,omitempty"`
+
+	ListOptions
+}
+
+// ServerUpdateRequest represents a request to update a server. Only the
+// non-nil fields are changed, the others keep their current value.
+type ServerUpdateRequest struct {
+	Name            *string
+	Tags            *[]string
+	DynamicPublicIP *bool
+	// BootScript is the ID of the bootscript to boot the server with.
+	BootScript *string
+	// SecurityGroup is the ID of the security group of the server.
+	SecurityGroup *string
+	// Volumes maps slots to volume IDs, "0" being the root volume. When
+	// non-nil, it replaces the volumes of the server: volumes missing from
+	// the map are detached.
+	Volumes map[string]string
+}
+
+// apply sets the non-nil fields of ur on the raw server definition.
+func (ur *ServerUpdateRequest) apply(server map[string]json.RawMessage) error {
+	type ref struct {
+		ID string `json:"id"`
+	}
+
+	changes := make(map[string]interface{})
+	if ur.Name != nil {
+		changes["name"] = *ur.Name
+	}
+	if ur.Tags != nil {
+		changes["tags"] = *ur.Tags
+	}
+	if ur.DynamicPublicIP != nil {
+		changes["dynamic_public_ip"] = *ur.DynamicPublicIP
+	}
+	if ur.BootScript != nil {
+		changes["bootscript"] = &ref{*ur.BootScript}
+	}
+	if ur.SecurityGroup != nil {
+		changes["security_group"] = &ref{*ur.SecurityGroup}
+	}
+	if ur.Volumes != nil {
+		volumes := make(map[string]*ref, len(ur.Volumes))
+		for slot, id := range ur.Volumes {
+			volumes[slot] = &ref{id}
+		}
+		changes["volumes"] = volumes
+	}
+
+	for key, v := range changes {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		server[key] = data
+	}
+	return nil
 }
 
 // serverResponse represents a Scaleway server creation response.
@@ -38,13 +192,24 @@ type serverResponse struct {
 	Server *Server `json:"server"`
 }
 
+// serverRawResponse represents a Scaleway server response, keeping every
+// attribute of the server, even the ones not modeled by Server.
+type serverRawResponse struct {
+	Server map[string]json.RawMessage `json:"server"`
+}
+
+// userDataListResponse represents a Scaleway user data keys list response.
+type userDataListResponse struct {
+	UserData []string `json:"user_data"`
+}
+
 // serverListResponse represents a Scaleway servers list response.
 type serverListResponse struct {
 	Servers []*Server `json:"servers"`
 }
 
 // Create creates images.
-func (s *ServersService) Create(sr *ServerRequest) (*Server, *Response, error) {
+func (s *ServersService) Create(ctx context.Context, sr *ServerRequest) (*Server, *Response, error) {
 	u := fmt.Sprintf("/servers")
 	req, err := s.client.NewRequestCompute("POST", u, sr)
 	if err != nil {
@@ -52,7 +217,7 @@ func (s *ServersService) Create(sr *ServerRequest) (*Server, *Response, error) {
 	}
 
 	server := new(serverResponse)
-	resp, err := s.client.Do(req, server)
+	resp, err := s.client.Do(ctx, req, server)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,35 +225,138 @@ func (s *ServersService) Create(sr *ServerRequest) (*Server, *Response, error) {
 }
 
 // List returns a list of all servers associate to your account.
-func (s *ServersService) List() ([]*Server, *Response, error) {
-	return s.listServers()
+func (s *ServersService) List(ctx context.Context, opt *ServerListOptions) ([]*Server, *Response, error) {
+	return s.listServers(ctx, opt)
 }
 
-func (s *ServersService) listServers() ([]*Server, *Response, error) {
-	u := fmt.Sprintf("/servers")
+func (s *ServersService) listServers(ctx context.Context, opt *ServerListOptions) ([]*Server, *Response, error) {
+	u, err := addOptions("/servers", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	servers := new(serverListResponse)
-	resp, err := s.client.Do(req, servers)
+	resp, err := s.client.Do(ctx, req, servers)
 	if err != nil {
 		return nil, nil, err
 	}
-	return servers.Servers, resp, nil
+	return opt.filter(servers.Servers), resp, nil
+}
+
+// ListPages calls fn for every page of servers, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *ServersService) ListPages(ctx context.Context, opt *ServerListOptions, fn func([]*Server) bool) error {
+	o := ServerListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		servers, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(servers), nil
+	})
+}
+
+// ListAll returns the servers of every page, starting from the page set in opt.
+func (s *ServersService) ListAll(ctx context.Context, opt *ServerListOptions) ([]*Server, error) {
+	var all []*Server
+	err := s.ListPages(ctx, opt, func(servers []*Server) bool {
+		all = append(all, servers...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ListAllRegions returns the servers of every page in every region of
+// regions, or of all the Scaleway regions if regions is empty. Regions are
+// queried concurrently and the servers are tagged with their region.
+func (s *ServersService) ListAllRegions(ctx context.Context, regions []Region, opt *ServerListOptions) ([]*Server, error) {
+	if len(regions) == 0 {
+		regions = Regions()
+	}
+	results := make(map[Region][]*Server, len(regions))
+	var mu sync.Mutex
+	err := s.client.EachRegion(ctx, regions, func(ctx context.Context, c *Client) error {
+		servers, err := c.Servers.ListAll(ctx, opt)
+		if err != nil {
+			return err
+		}
+		for _, server := range servers {
+			server.Region = c.Region
+		}
+		mu.Lock()
+		results[c.Region] = servers
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Server
+	for _, region := range regions {
+		all = append(all, results[region]...)
+	}
+	return all, nil
 }
 
 // Get returns info for a specific server.
-func (s *ServersService) Get(id string) (*Server, *Response, error) {
+func (s *ServersService) Get(ctx context.Context, id string) (*Server, *Response, error) {
+	u := fmt.Sprintf("/servers/%s", id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	server := new(serverResponse)
+	resp, err := s.client.Do(ctx, req, server)
+	if err != nil {
+		return nil, nil, err
+	}
+	return server.Server, resp, nil
+}
+
+// Update updates the details about a server. The API only accepts full
+// server definitions, so the current definition is fetched first and the
+// fields set in ur are applied to it.
+func (s *ServersService) Update(ctx context.Context, id string, ur *ServerUpdateRequest) (*Server, *Response, error) {
 	u := fmt.Sprintf("/servers/%s", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	current := new(serverRawResponse)
+	_, err = s.client.Do(ctx, req, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current.Server == nil {
+		current.Server = make(map[string]json.RawMessage)
+	}
+	if err := ur.apply(current.Server); err != nil {
+		return nil, nil, err
+	}
+
+	req, err = s.client.NewRequestCompute("PUT", u, current.Server)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	server := new(serverResponse)
-	resp, err := s.client.Do(req, server)
+	resp, err := s.client.Do(ctx, req, server)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,13 +364,95 @@ func (s *ServersService) Get(id string) (*Server, *Response, error) {
 }
 
 // Delete deletes a server.
-func (s *ServersService) Delete(id string) (*Response, error) {
-	u := fmt.Sprintf("/server/%s", id)
+func (s *ServersService) Delete(ctx context.Context, id string) (*Response, error) {
+	u := fmt.Sprintf("/servers/%s", id)
+	req, err := s.client.NewRequestCompute("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// filter returns the servers matching the filters of opt.
+func (opt *ServerListOptions) filter(servers []*Server) []*Server {
+	if opt == nil {
+		return servers
+	}
+	filtered := servers[:0]
+	for _, s := range servers {
+		if !strings.Contains(s.Name, opt.Name) ||
+			!matchString(s.State, opt.State) ||
+			!matchTags(s.Tags, opt.Tags) ||
+			!matchString(s.Organization, opt.Organization) ||
+			!matchString(s.CommercialType, opt.CommercialType) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
+// ListUserData returns the user data keys of a server.
+func (s *ServersService) ListUserData(ctx context.Context, id string) ([]string, *Response, error) {
+	u := fmt.Sprintf("/servers/%s/user_data", id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := new(userDataListResponse)
+	resp, err := s.client.Do(ctx, req, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys.UserData, resp, nil
+}
+
+// GetUserData returns the raw value of the user data key of a server.
+func (s *ServersService) GetUserData(ctx context.Context, id, key string) ([]byte, *Response, error) {
+	u := fmt.Sprintf("/servers/%s/user_data/%s", id, key)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value.Bytes(), resp, nil
+}
+
+// SetUserData sets the user data key of a server to the content of value,
+// e.g. a cloud-init configuration.
+func (s *ServersService) SetUserData(ctx context.Context, id, key string, value io.Reader) (*Response, error) {
+	u := fmt.Sprintf("/servers/%s/user_data/%s", id, key)
+	req, err := s.client.NewRequestCompute("PATCH", u, value)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteUserData deletes the user data key of a server.
+func (s *ServersService) DeleteUserData(ctx context.Context, id, key string) (*Response, error) {
+	u := fmt.Sprintf("/servers/%s/user_data/%s", id, key)
 	req, err := s.client.NewRequestCompute("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
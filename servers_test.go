@@ -1,12 +1,17 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestServersService_Create(t *testing.T) {
@@ -36,13 +41,13 @@ func TestServersService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	server, _, err := client.Servers.Create(inBody)
+	server, _, err := client.Servers.Create(context.Background(), inBody)
 	if err != nil {
 		t.Errorf("Servers.Create returned error: %v", err)
 	}
 
 	want := &Server{
-		BootScript:      "",
+		BootScript:      nil,
 		DynamicPublicIP: false,
 		ID:              "3cb18e2d-f4f7-48f7-b452-59b88ae8fc8c",
 		Image: &Image{
@@ -86,14 +91,14 @@ func TestServersService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	servers, _, err := client.Servers.List()
+	servers, _, err := client.Servers.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Servers.List returned error: %v", err)
 	}
 
 	want := []*Server{
 		{
-			BootScript:      "",
+			BootScript:      nil,
 			DynamicPublicIP: false,
 			ID:              "741db378-6b87-46d4-a8c5-4e46a09ab1f8",
 			Image: &Image{
@@ -132,20 +137,41 @@ func TestServersService_Get(t *testing.T) {
 
 	data := testOpenFixture(t, filepath.Join(fixtureDir, "servers_get.json"))
 
+	creationDate, _ := time.Parse(timeLayout, "2016-05-20T13:42:08.219341+00:00")
+	modificationDate, _ := time.Parse(timeLayout, "2016-05-20T13:45:11.519734+00:00")
 	want := &Server{
-		BootScript:      "",
+		Arch:            "x86_64",
+		BootScript:      nil,
+		BootType:        "local",
+		CommercialType:  "VC1S",
+		CreationDate:    Ntime(creationDate),
 		DynamicPublicIP: false,
+		EnableIPv6:      true,
+		Hostname:        "my-server",
 		ID:              "741db378-6b87-46d4-a8c5-4e46a09ab1f8",
 		Image: &Image{
 			ID:   "85917034-46b0-4cc5-8b48-f0a2245e357e",
 			Name: "archlinux working",
 		},
-		Name:         "my_server",
-		Organization: "000a115d-2852-4b0a-9ce8-47f1134ba95a",
-		PrivateIP:    "",
-		PublicIP:     "",
-		State:        "running",
-		Tags:         []string{"test", "www"},
+		IPv6: &ServerIPv6{
+			Address: "2001:bc8:4400:2000::1c05",
+			Gateway: "2001:bc8:4400:2000::1c04",
+			Netmask: "127",
+		},
+		Location: &ServerLocation{
+			ClusterID:    "11",
+			HypervisorID: "1201",
+			NodeID:       "3",
+			PlatformID:   "13",
+			ZoneID:       "par1",
+		},
+		ModificationDate: Ntime(modificationDate),
+		Name:             "my_server",
+		Organization:     "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		PrivateIP:        "",
+		PublicIP:         "",
+		State:            "running",
+		Tags:             []string{"test", "www"},
 		Volumes: map[string]*Volume{
 			"0": {
 				ExportURI:    "",
@@ -164,7 +190,7 @@ func TestServersService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	server, _, err := client.Servers.Get(want.ID)
+	server, _, err := client.Servers.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Servers.Get returned error: %v", err)
 	}
@@ -186,8 +212,356 @@ func TestServersService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.Servers.Delete(serverID)
+	_, err := client.Servers.Delete(context.Background(), serverID)
 	if err != nil {
 		t.Errorf("Servers.Delete returned error: %v", err)
 	}
 }
+
+func TestServersService_Get_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type":"unknown_resource","message":"\"741db378\" not found"}`)
+	})
+
+	server, _, err := client.Servers.Get(context.Background(), serverID)
+	if err == nil {
+		t.Fatalf("Servers.Get returned server %+v, want error", server)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Servers.Get returned error %v, want a not found error", err)
+	}
+}
+
+func TestServersService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("per_page"), "1"; got != want {
+			t.Errorf("Request per_page = %v, want %v", got, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		w.Header().Add("X-Total-Count", "2")
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Add("Link", `</servers?page=2&per_page=1>; rel="next", </servers?page=2&per_page=1>; rel="last"`)
+			fmt.Fprint(w, `{"servers": [{"id": "1"}]}`)
+		case "2":
+			w.Header().Add("Link", `</servers?page=1&per_page=1>; rel="first", </servers?page=1&per_page=1>; rel="previous"`)
+			fmt.Fprint(w, `{"servers": [{"id": "2"}]}`)
+		default:
+			t.Errorf("Unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+
+	servers, err := client.Servers.ListAll(context.Background(), &ServerListOptions{ListOptions: ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Errorf("Servers.ListAll returned error: %v", err)
+	}
+
+	want := []*Server{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(servers, want) {
+		t.Errorf("Servers.ListAll returned %+v\n, want %+v", servers, want)
+	}
+
+	pages := 0
+	err = client.Servers.ListPages(context.Background(), &ServerListOptions{ListOptions: ListOptions{PerPage: 1}}, func([]*Server) bool {
+		pages++
+		return false
+	})
+	if err != nil {
+		t.Errorf("Servers.ListPages returned error: %v", err)
+	}
+	if pages != 1 {
+		t.Errorf("Servers.ListPages fetched %d pages, want 1", pages)
+	}
+}
+
+func TestServersService_List_filter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := url.Values{
+			"name":            {"web"},
+			"state":           {"running"},
+			"tags":            {"prod", "www"},
+			"commercial_type": {"VC1S"},
+		}
+		if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Request query = %v, want %v", got, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		// pretend the API ignored the filters
+		fmt.Fprint(w, `{"servers": [
+			{"id": "1", "name": "web-1", "state": "running", "commercial_type": "VC1S", "tags": ["www", "prod"]},
+			{"id": "2", "name": "web-2", "state": "stopped", "commercial_type": "VC1S", "tags": ["www", "prod"]},
+			{"id": "3", "name": "web-3", "state": "running", "commercial_type": "VC1S", "tags": ["www"]},
+			{"id": "4", "name": "db-1", "state": "running", "commercial_type": "VC1S", "tags": ["www", "prod"]},
+			{"id": "5", "name": "web-5", "state": "running", "commercial_type": "C2M", "tags": ["www", "prod"]}
+		]}`)
+	})
+
+	opt := &ServerListOptions{
+		Name:           "web",
+		State:          "running",
+		Tags:           []string{"prod", "www"},
+		CommercialType: "VC1S",
+	}
+	servers, _, err := client.Servers.List(context.Background(), opt)
+	if err != nil {
+		t.Errorf("Servers.List returned error: %v", err)
+	}
+
+	var ids []string
+	for _, s := range servers {
+		ids = append(ids, s.ID)
+	}
+	if want := []string{"1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Servers.List returned servers %v, want %v", ids, want)
+	}
+}
+
+func TestServersService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "servers_get.json"))
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", contentType)
+		if r.Method == "GET" {
+			fmt.Fprint(w, string(data))
+			return
+		}
+		testMethod(t, r, "PUT")
+
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		// updated attributes
+		if got, want := v["name"], "renamed"; got != want {
+			t.Errorf("Request name = %v, want %v", got, want)
+		}
+		if got, want := v["tags"], []interface{}{"prod"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Request tags = %v, want %v", got, want)
+		}
+		wantVolumes := map[string]interface{}{
+			"0": map[string]interface{}{"id": "c1eb8f3a-4f0b-4b95-a71c-93223e457f5a"},
+			"1": map[string]interface{}{"id": "f929fe39-63f8-4be8-a80e-1e9c8ae22a76"},
+		}
+		if got := v["volumes"]; !reflect.DeepEqual(got, wantVolumes) {
+			t.Errorf("Request volumes = %v, want %v", got, wantVolumes)
+		}
+		// untouched attributes
+		if got, want := v["organization"], "000a115d-2852-4b0a-9ce8-47f1134ba95a"; got != want {
+			t.Errorf("Request organization = %v, want %v", got, want)
+		}
+		if got, want := v["dynamic_public_ip"], false; got != want {
+			t.Errorf("Request dynamic_public_ip = %v, want %v", got, want)
+		}
+
+		fmt.Fprintf(w, `{"server": {"id": %q, "name": "renamed", "tags": ["prod"]}}`, serverID)
+	})
+
+	ur := &ServerUpdateRequest{
+		Name: String("renamed"),
+		Tags: &[]string{"prod"},
+		Volumes: map[string]string{
+			"0": "c1eb8f3a-4f0b-4b95-a71c-93223e457f5a",
+			"1": "f929fe39-63f8-4be8-a80e-1e9c8ae22a76",
+		},
+	}
+	server, _, err := client.Servers.Update(context.Background(), serverID, ur)
+	if err != nil {
+		t.Errorf("Servers.Update returned error: %v", err)
+	}
+
+	want := &Server{ID: serverID, Name: "renamed", Tags: []string{"prod"}}
+	if !reflect.DeepEqual(server, want) {
+		t.Errorf("Servers.Update returned %+v\n, want %+v", server, want)
+	}
+}
+
+func TestServersService_Create_full(t *testing.T) {
+	setup()
+	defer teardown()
+
+	inBody := &ServerRequest{
+		Organization:      "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		Name:              "my_server",
+		Image:             "85917034-46b0-4cc5-8b48-f0a2245e357e",
+		Tags:              []string{"www"},
+		CommercialType:    "VC1S",
+		Volumes:           map[string]string{"1": "f929fe39-63f8-4be8-a80e-1e9c8ae22a76"},
+		BootScript:        "599b736c-48b5-4530-9764-f04d06ecadc7",
+		BootType:          "bootscript",
+		DynamicIPRequired: Bool(false),
+		PublicIP:          "b5ab7e2f-5f9d-4a52-8c46-64a8e1ba4e5a",
+		EnableIPv6:        true,
+		SecurityGroup:     "1d3f0e36-2d2c-4c36-8d6e-c3d3d5b9a1c4",
+	}
+
+	mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		testMethod(t, r, "POST")
+		want := map[string]interface{}{
+			"organization":        "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+			"name":                "my_server",
+			"image":               "85917034-46b0-4cc5-8b48-f0a2245e357e",
+			"tags":                []interface{}{"www"},
+			"commercial_type":     "VC1S",
+			"volumes":             map[string]interface{}{"1": "f929fe39-63f8-4be8-a80e-1e9c8ae22a76"},
+			"bootscript":          "599b736c-48b5-4530-9764-f04d06ecadc7",
+			"boot_type":           "bootscript",
+			"dynamic_ip_required": false,
+			"public_ip":           "b5ab7e2f-5f9d-4a52-8c46-64a8e1ba4e5a",
+			"enable_ipv6":         true,
+			"security_group":      "1d3f0e36-2d2c-4c36-8d6e-c3d3d5b9a1c4",
+		}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"server": {"id": "3cb18e2d-f4f7-48f7-b452-59b88ae8fc8c"}}`)
+	})
+
+	_, _, err := client.Servers.Create(context.Background(), inBody)
+	if err != nil {
+		t.Errorf("Servers.Create returned error: %v", err)
+	}
+}
+
+func TestServer_ExtraVolumes(t *testing.T) {
+	server := &Server{
+		Volumes: map[string]*Volume{
+			"0":  {ID: "root"},
+			"10": {ID: "ten"},
+			"2":  {ID: "two"},
+			"1":  {ID: "one"},
+		},
+	}
+
+	var ids []string
+	for _, v := range server.ExtraVolumes() {
+		ids = append(ids, v.ID)
+	}
+	if want := []string{"one", "two", "ten"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ExtraVolumes returned %v, want %v", ids, want)
+	}
+}
+
+func TestServersService_ListUserData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s/user_data", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"user_data": ["cloud-init", "ssh-host-fingerprints"]}`)
+	})
+
+	keys, _, err := client.Servers.ListUserData(context.Background(), serverID)
+	if err != nil {
+		t.Errorf("Servers.ListUserData returned error: %v", err)
+	}
+	if want := []string{"cloud-init", "ssh-host-fingerprints"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Servers.ListUserData returned %v, want %v", keys, want)
+	}
+}
+
+func TestServersService_GetUserData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+	cloudInit := "#cloud-config\npackages:\n  - nginx\n"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s/user_data/cloud-init", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "text/plain")
+		fmt.Fprint(w, cloudInit)
+	})
+
+	value, _, err := client.Servers.GetUserData(context.Background(), serverID, "cloud-init")
+	if err != nil {
+		t.Errorf("Servers.GetUserData returned error: %v", err)
+	}
+	if got := string(value); got != cloudInit {
+		t.Errorf("Servers.GetUserData returned %q, want %q", got, cloudInit)
+	}
+}
+
+func TestServersService_SetUserData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+	cloudInit := "#cloud-config\npackages:\n  - nginx\n"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s/user_data/cloud-init", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		if got, want := r.Header.Get("Content-Type"), "text/plain"; got != want {
+			t.Errorf("Request Content-Type = %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if got := string(body); got != cloudInit {
+			t.Errorf("Request body = %q, want %q", got, cloudInit)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Servers.SetUserData(context.Background(), serverID, "cloud-init", strings.NewReader(cloudInit))
+	if err != nil {
+		t.Errorf("Servers.SetUserData returned error: %v", err)
+	}
+}
+
+func TestServersService_DeleteUserData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s/user_data/cloud-init", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Servers.DeleteUserData(context.Background(), serverID, "cloud-init")
+	if err != nil {
+		t.Errorf("Servers.DeleteUserData returned error: %v", err)
+	}
+}
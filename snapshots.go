@@ -1,6 +1,11 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // SnapshotsService handles communication with the tokens related
 // methods of the Scaleway API.
@@ -21,6 +26,8 @@ type Snapshot struct {
 	State            string  `json:"state,omitempty"`
 	Type             string  `json:"volume_type,omitempty"`
 	BaseVolume       *Volume `json:"base_volume,omitempty"`
+	// Region the snapshot lives in. Only set by ListAllRegions.
+	Region Region `json:"-"`
 }
 
 // SnapshotRequest represents a request to create a snapshot.
@@ -30,6 +37,20 @@ type SnapshotRequest struct {
 	Volume       string `json:"volume_id,omitempty"`
 }
 
+// SnapshotListOptions specifies the optional parameters to the
+// SnapshotsService.List method. Filters are sent as query parameters and
+// also applied to the returned snapshots, in case the API ignores them.
+type SnapshotListOptions struct {
+	// Name filters snapshots whose name contains the given string.
+	Name string `url:"name,omitempty"`
+	// Organization filters snapshots owned by the given organization.
+	Organization string `url:"organization,omitempty"`
+	// State filters snapshots in the given state, e.g. "available".
+	State string `url:"state,omitempty"`
+
+	ListOptions
+}
+
 // snapshotResponse represents a Scaleway snapshot creation response.
 type snapshotResponse struct {
 	Snapshot *Snapshot `json:"snapshot"`
@@ -41,7 +62,7 @@ type snapshotListResponse struct {
 }
 
 // Create create snapshot of a volume.
-func (s *SnapshotsService) Create(sr *SnapshotRequest) (*Snapshot, *Response, error) {
+func (s *SnapshotsService) Create(ctx context.Context, sr *SnapshotRequest) (*Snapshot, *Response, error) {
 	u := fmt.Sprintf("/snapshots")
 	req, err := s.client.NewRequestCompute("POST", u, sr)
 	if err != nil {
@@ -49,7 +70,7 @@ func (s *SnapshotsService) Create(sr *SnapshotRequest) (*Snapshot, *Response, er
 	}
 
 	snapshot := new(snapshotResponse)
-	resp, err := s.client.Do(req, snapshot)
+	resp, err := s.client.Do(ctx, req, snapshot)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -57,27 +78,95 @@ func (s *SnapshotsService) Create(sr *SnapshotRequest) (*Snapshot, *Response, er
 }
 
 // List returns a list of all snapshots associate to your account.
-func (s *SnapshotsService) List() ([]*Snapshot, *Response, error) {
-	return s.listSnapshots()
+func (s *SnapshotsService) List(ctx context.Context, opt *SnapshotListOptions) ([]*Snapshot, *Response, error) {
+	return s.listSnapshots(ctx, opt)
 }
 
-func (s *SnapshotsService) listSnapshots() ([]*Snapshot, *Response, error) {
-	u := fmt.Sprintf("/snapshots")
+func (s *SnapshotsService) listSnapshots(ctx context.Context, opt *SnapshotListOptions) ([]*Snapshot, *Response, error) {
+	u, err := addOptions("/snapshots", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	snapshots := new(snapshotListResponse)
-	resp, err := s.client.Do(req, snapshots)
+	resp, err := s.client.Do(ctx, req, snapshots)
 	if err != nil {
 		return nil, nil, err
 	}
-	return snapshots.Snapshots, resp, nil
+	return opt.filter(snapshots.Snapshots), resp, nil
+}
+
+// ListPages calls fn for every page of snapshots, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *SnapshotsService) ListPages(ctx context.Context, opt *SnapshotListOptions, fn func([]*Snapshot) bool) error {
+	o := SnapshotListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		snapshots, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(snapshots), nil
+	})
+}
+
+// ListAll returns the snapshots of every page, starting from the page set in opt.
+func (s *SnapshotsService) ListAll(ctx context.Context, opt *SnapshotListOptions) ([]*Snapshot, error) {
+	var all []*Snapshot
+	err := s.ListPages(ctx, opt, func(snapshots []*Snapshot) bool {
+		all = append(all, snapshots...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ListAllRegions returns the snapshots of every page in every region of
+// regions, or of all the Scaleway regions if regions is empty. Regions are
+// queried concurrently and the snapshots are tagged with their region.
+func (s *SnapshotsService) ListAllRegions(ctx context.Context, regions []Region, opt *SnapshotListOptions) ([]*Snapshot, error) {
+	if len(regions) == 0 {
+		regions = Regions()
+	}
+	results := make(map[Region][]*Snapshot, len(regions))
+	var mu sync.Mutex
+	err := s.client.EachRegion(ctx, regions, func(ctx context.Context, c *Client) error {
+		snapshots, err := c.Snapshots.ListAll(ctx, opt)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			snapshot.Region = c.Region
+		}
+		mu.Lock()
+		results[c.Region] = snapshots
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Snapshot
+	for _, region := range regions {
+		all = append(all, results[region]...)
+	}
+	return all, nil
 }
 
 // Get returns info for a specific snapshot.
-func (s *SnapshotsService) Get(id string) (*Snapshot, *Response, error) {
+func (s *SnapshotsService) Get(ctx context.Context, id string) (*Snapshot, *Response, error) {
 	u := fmt.Sprintf("/snapshots/%s", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
@@ -85,7 +174,7 @@ func (s *SnapshotsService) Get(id string) (*Snapshot, *Response, error) {
 	}
 
 	snapshot := new(snapshotResponse)
-	resp, err := s.client.Do(req, snapshot)
+	resp, err := s.client.Do(ctx, req, snapshot)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -93,7 +182,7 @@ func (s *SnapshotsService) Get(id string) (*Snapshot, *Response, error) {
 }
 
 // Update updates the details about snapshot.
-func (s *SnapshotsService) Update(id string, sr *SnapshotRequest) (*Snapshot, *Response, error) {
+func (s *SnapshotsService) Update(ctx context.Context, id string, sr *SnapshotRequest) (*Snapshot, *Response, error) {
 	u := fmt.Sprintf("/snapshots/%s", id)
 	req, err := s.client.NewRequestCompute("PUT", u, sr)
 	if err != nil {
@@ -101,7 +190,7 @@ func (s *SnapshotsService) Update(id string, sr *SnapshotRequest) (*Snapshot, *R
 	}
 
 	snapshot := new(snapshotResponse)
-	resp, err := s.client.Do(req, snapshot)
+	resp, err := s.client.Do(ctx, req, snapshot)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,15 +198,32 @@ func (s *SnapshotsService) Update(id string, sr *SnapshotRequest) (*Snapshot, *R
 }
 
 // Delete deletes a snapshot.
-func (s *SnapshotsService) Delete(id string) (*Response, error) {
+func (s *SnapshotsService) Delete(ctx context.Context, id string) (*Response, error) {
 	u := fmt.Sprintf("/snapshots/%s", id)
 	req, err := s.client.NewRequestCompute("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
+
+// filter returns the snapshots matching the filters of opt.
+func (opt *SnapshotListOptions) filter(snapshots []*Snapshot) []*Snapshot {
+	if opt == nil {
+		return snapshots
+	}
+	filtered := snapshots[:0]
+	for _, s := range snapshots {
+		if !strings.Contains(s.Name, opt.Name) ||
+			!matchString(s.Organization, opt.Organization) ||
+			!matchString(s.State, opt.State) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -38,7 +39,7 @@ func TestSnapshotsService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	snapshot, _, err := client.Snapshots.Create(inBody)
+	snapshot, _, err := client.Snapshots.Create(context.Background(), inBody)
 	if err != nil {
 		t.Errorf("Snapshots.Create returned error: %v", err)
 	}
@@ -76,7 +77,7 @@ func TestSnapshotsService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	snapshots, _, err := client.Snapshots.List()
+	snapshots, _, err := client.Snapshots.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Snaphosts.List returned error: %v", err)
 	}
@@ -132,7 +133,7 @@ func TestSnapshotsService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	snapshot, _, err := client.Snapshots.Get(want.ID)
+	snapshot, _, err := client.Snapshots.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Snapshots.Get returned error: %v", err)
 	}
@@ -176,7 +177,7 @@ func TestSnapshotsService_Update(t *testing.T) {
 		Organization: "000a115d-2852-4b0a-9ce8-47f1134ba95a",
 	}
 
-	snapshot, _, err := client.Snapshots.Update(want.ID, inBody)
+	snapshot, _, err := client.Snapshots.Update(context.Background(), want.ID, inBody)
 	if err != nil {
 		t.Errorf("Snapshots.Update returned error: %v", err)
 	}
@@ -200,7 +201,7 @@ func TestSnapshotsService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.Snapshots.Delete(snapshotID)
+	_, err := client.Snapshots.Delete(context.Background(), snapshotID)
 	if err != nil {
 		t.Errorf("Snapshots.Delete returned error: %v", err)
 	}
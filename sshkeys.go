@@ -0,0 +1,94 @@
+package scaleway
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// sshKeyTypes lists the SSH public key algorithms accepted by ParseSSHKey.
+var sshKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-dss":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// SSHKey represents an SSH public key of a Scaleway user.
+type SSHKey struct {
+	// Key is the public key in the authorized_keys format, e.g.
+	// "ssh-ed25519 AAAA... jsnow@got.com".
+	Key string `json:"key"`
+	// Fingerprint is the MD5 fingerprint of the key, e.g.
+	// "d5:8b:1e:...".
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Type is the algorithm of the key, e.g. "ssh-rsa".
+	Type string `json:"-"`
+	// Comment is the optional comment following the key.
+	Comment string `json:"-"`
+}
+
+// ParseSSHKey parses an SSH public key in the authorized_keys format and
+// returns an error if it is malformed.
+func ParseSSHKey(s string) (*SSHKey, error) {
+	key, _, err := parseSSHKey(s)
+	return key, err
+}
+
+// parseSSHKey parses an SSH public key as ParseSSHKey does, and also
+// returns its decoded blob.
+func parseSSHKey(s string) (*SSHKey, []byte, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return nil, nil, fmt.Errorf("invalid SSH public key: want \"<type> <base64 key> [comment]\"")
+	}
+	keyType, encoded := fields[0], fields[1]
+	if !sshKeyTypes[keyType] {
+		return nil, nil, fmt.Errorf("invalid SSH public key: unknown key type %q", keyType)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid SSH public key: %v", err)
+	}
+	// The key blob starts with the key type, prefixed by its length.
+	if len(blob) < 4 {
+		return nil, nil, fmt.Errorf("invalid SSH public key: key data too short")
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint64(len(blob)-4) < uint64(n) || string(blob[4:4+n]) != keyType {
+		return nil, nil, fmt.Errorf("invalid SSH public key: key data does not match type %q", keyType)
+	}
+
+	key := &SSHKey{
+		Type:        keyType,
+		Comment:     strings.Join(fields[2:], " "),
+		Fingerprint: sshFingerprint(blob),
+	}
+	key.Key = keyType + " " + encoded
+	if key.Comment != "" {
+		key.Key += " " + key.Comment
+	}
+	return key, blob, nil
+}
+
+// sshFingerprint returns the MD5 fingerprint of a key blob.
+func sshFingerprint(blob []byte) string {
+	sum := md5.Sum(blob)
+	var buf bytes.Buffer
+	for i, b := range sum {
+		if i > 0 {
+			buf.WriteByte(':')
+		}
+		fmt.Fprintf(&buf, "%02x", b)
+	}
+	return buf.String()
+}
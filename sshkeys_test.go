@@ -0,0 +1,55 @@
+package scaleway
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	testSSHKeyEd25519     = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f jsnow@got.com"
+	testSSHKeyEd25519Hash = "0f:a2:0a:d7:38:3e:65:45:08:6b:63:84:1c:ff:dc:ba"
+	testSSHKeyRSA         = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAABQDDGis8"
+	testSSHKeyRSAHash     = "47:f1:c3:0c:6a:1c:78:86:3f:af:20:d4:2e:54:bf:b9"
+)
+
+func TestParseSSHKey(t *testing.T) {
+	key, err := ParseSSHKey("  " + testSSHKeyEd25519 + "\n")
+	if err != nil {
+		t.Fatalf("ParseSSHKey returned error: %v", err)
+	}
+
+	want := &SSHKey{
+		Key:         testSSHKeyEd25519,
+		Fingerprint: testSSHKeyEd25519Hash,
+		Type:        "ssh-ed25519",
+		Comment:     "jsnow@got.com",
+	}
+	if !reflect.DeepEqual(key, want) {
+		t.Errorf("ParseSSHKey returned %+v, want %+v", key, want)
+	}
+
+	key, err = ParseSSHKey(testSSHKeyRSA)
+	if err != nil {
+		t.Fatalf("ParseSSHKey returned error: %v", err)
+	}
+	if key.Comment != "" || key.Fingerprint != testSSHKeyRSAHash {
+		t.Errorf("ParseSSHKey returned %+v, want no comment and fingerprint %v", key, testSSHKeyRSAHash)
+	}
+}
+
+func TestParseSSHKey_invalid(t *testing.T) {
+	keys := []string{
+		"",
+		"ssh-ed25519",
+		"ssh-foo AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f",
+		"ssh-ed25519 not-base64!",
+		"ssh-ed25519 AAA=",
+		"ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f",
+		"ssh-ed25519 AAAA/3NzaC1lZDI1NTE5",
+	}
+	for _, k := range keys {
+		if key, err := ParseSSHKey(k); err == nil {
+			t.Errorf("ParseSSHKey(%q) = %+v, want error", k, key)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Task statuses.
+const (
+	TaskPending = "pending"
+	TaskStarted = "started"
+	TaskSuccess = "success"
+	TaskFailure = "failure"
+)
+
+// TasksService handles communication with the tasks related
+// methods of the Scaleway API.
+//
+// Scaleway API docs: https://developer.scaleway.com/#tasks
+type TasksService struct {
+	client *Client
+}
+
+// Task represents an asynchronous action, such as the one returned when
+// executing an action on a server.
+type Task struct {
+	Description     string `json:"description,omitempty"`
+	HrefFrom        string `json:"href_from,omitempty"`
+	ID              string `json:"id,omitempty"`
+	Progress        string `json:"progress,omitempty"`
+	StartDate       Ntime  `json:"started_at,omitempty"`
+	Status          string `json:"status,omitempty"`
+	TerminationDate Ntime  `json:"terminated_at,omitempty"`
+}
+
+// ServerID returns the ID of the server the task originates from, parsed
+// from HrefFrom, or an empty string if the task isn't related to a server.
+func (t *Task) ServerID() string {
+	parts := strings.Split(strings.Trim(t.HrefFrom, "/"), "/")
+	if len(parts) < 2 || parts[0] != "servers" {
+		return ""
+	}
+	return parts[1]
+}
+
+// taskResponse represents a Scaleway task response.
+type taskResponse struct {
+	Task *Task `json:"task"`
+}
+
+// taskListResponse represents a Scaleway tasks list response.
+type taskListResponse struct {
+	Tasks []*Task `json:"tasks"`
+}
+
+// List returns a list of all tasks associate to your account.
+func (s *TasksService) List(ctx context.Context, opt *ListOptions) ([]*Task, *Response, error) {
+	return s.listTasks(ctx, opt)
+}
+
+func (s *TasksService) listTasks(ctx context.Context, opt *ListOptions) ([]*Task, *Response, error) {
+	u, err := addOptions("/tasks", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := new(taskListResponse)
+	resp, err := s.client.Do(ctx, req, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks.Tasks, resp, nil
+}
+
+// ListPages calls fn for every page of tasks, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *TasksService) ListPages(ctx context.Context, opt *ListOptions, fn func([]*Task) bool) error {
+	return walkPages(opt, func(opt *ListOptions) (*Response, bool, error) {
+		tasks, resp, err := s.List(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(tasks), nil
+	})
+}
+
+// ListAll returns the tasks of every page, starting from the page set in opt.
+func (s *TasksService) ListAll(ctx context.Context, opt *ListOptions) ([]*Task, error) {
+	var all []*Task
+	err := s.ListPages(ctx, opt, func(tasks []*Task) bool {
+		all = append(all, tasks...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Get returns info for a specific task.
+func (s *TasksService) Get(ctx context.Context, id string) (*Task, *Response, error) {
+	u := fmt.Sprintf("/tasks/%s", id)
+	req, err := s.client.NewRequestCompute("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(taskResponse)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task.Task, resp, nil
+}
+
+// Delete deletes a task.
+func (s *TasksService) Delete(ctx context.Context, id string) (*Response, error) {
+	u := fmt.Sprintf("/tasks/%s", id)
+	req, err := s.client.NewRequestCompute("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Server returns the server the task originates from.
+func (s *TasksService) Server(ctx context.Context, t *Task) (*Server, *Response, error) {
+	id := t.ServerID()
+	if id == "" {
+		return nil, nil, fmt.Errorf("task %s does not originate from a server: %q", t.ID, t.HrefFrom)
+	}
+	return s.client.Servers.Get(ctx, id)
+}
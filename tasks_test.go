@@ -0,0 +1,142 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTasksService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "tasks_list.json"))
+
+	mux.HandleFunc("/tasks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	tasks, _, err := client.Tasks.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Tasks.List returned error: %v", err)
+	}
+
+	startDate, _ := time.Parse(timeLayout, "2014-05-22T12:57:22.514298+00:00")
+	want := []*Task{
+		{
+			Description: "server_poweroff",
+			HrefFrom:    "/servers/741db378-6b87-46d4-a8c5-4e46a09ab1f8/action",
+			ID:          "a8a1775c-0dda-4f52-87b2-4e8101d68d6e",
+			Progress:    "0",
+			StartDate:   Ntime(startDate),
+			Status:      TaskPending,
+		},
+	}
+	if !reflect.DeepEqual(tasks, want) {
+		t.Errorf("Tasks.List returned %+v\n, want %+v", tasks, want)
+	}
+}
+
+func TestTasksService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	data := testOpenFixture(t, filepath.Join(fixtureDir, "tasks_get.json"))
+
+	startDate, _ := time.Parse(timeLayout, "2014-05-22T12:57:22.514298+00:00")
+	terminationDate, _ := time.Parse(timeLayout, "2014-05-22T12:58:03.124538+00:00")
+	want := &Task{
+		Description:     "server_poweroff",
+		HrefFrom:        "/servers/741db378-6b87-46d4-a8c5-4e46a09ab1f8/action",
+		ID:              "a8a1775c-0dda-4f52-87b2-4e8101d68d6e",
+		Progress:        "100",
+		StartDate:       Ntime(startDate),
+		Status:          TaskSuccess,
+		TerminationDate: Ntime(terminationDate),
+	}
+
+	mux.HandleFunc(fmt.Sprintf("/tasks/%s", want.ID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, string(data))
+	})
+
+	task, _, err := client.Tasks.Get(context.Background(), want.ID)
+	if err != nil {
+		t.Errorf("Tasks.Get returned error: %v", err)
+	}
+	if !reflect.DeepEqual(task, want) {
+		t.Errorf("Tasks.Get returned %+v\n, want %+v", task, want)
+	}
+}
+
+func TestTasksService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	taskID := "a8a1775c-0dda-4f52-87b2-4e8101d68d6e"
+
+	mux.HandleFunc(fmt.Sprintf("/tasks/%s", taskID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Tasks.Delete(context.Background(), taskID)
+	if err != nil {
+		t.Errorf("Tasks.Delete returned error: %v", err)
+	}
+}
+
+func TestTasksService_Server(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"server": {"id": %q}}`, serverID)
+	})
+
+	task := &Task{HrefFrom: fmt.Sprintf("/servers/%s/action", serverID)}
+	server, _, err := client.Tasks.Server(context.Background(), task)
+	if err != nil {
+		t.Errorf("Tasks.Server returned error: %v", err)
+	}
+	if want := (&Server{ID: serverID}); !reflect.DeepEqual(server, want) {
+		t.Errorf("Tasks.Server returned %+v, want %+v", server, want)
+	}
+
+	if _, _, err := client.Tasks.Server(context.Background(), &Task{HrefFrom: "/images/foo"}); err == nil {
+		t.Error("Tasks.Server succeeded for a task not related to a server")
+	}
+}
+
+func TestTask_ServerID(t *testing.T) {
+	tests := map[string]string{
+		"/servers/741db378-6b87-46d4-a8c5-4e46a09ab1f8/action": "741db378-6b87-46d4-a8c5-4e46a09ab1f8",
+		"/servers/741db378-6b87-46d4-a8c5-4e46a09ab1f8":        "741db378-6b87-46d4-a8c5-4e46a09ab1f8",
+		"/images/98bf3ac2-a1f5-471d-8c8f-1b706ab57ef0":         "",
+		"": "",
+	}
+	for href, want := range tests {
+		task := &Task{HrefFrom: href}
+		if got := task.ServerID(); got != want {
+			t.Errorf("Task{HrefFrom: %q}.ServerID() = %q, want %q", href, got, want)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -15,22 +17,85 @@ type TokensService struct {
 
 // Token represents a Scaleway auth-token.
 type Token struct {
-	CreationDate      Ntime    `json:"creation_date,omitempty"`
-	Expires           Ntime    `json:"expires,omitempty"`
-	ID                string   `json:"id,omitempty"`
-	InheritsUserPerms bool     `json:"inherits_user_perms,omitempty"`
-	UserID            string   `json:"user_id,omitempty"`
-	Permission        []string `json:"permissions"`
+	CreationDate      Ntime       `json:"creation_date,omitempty"`
+	Expires           Ntime       `json:"expires,omitempty"`
+	ID                string      `json:"id,omitempty"`
+	InheritsUserPerms bool        `json:"inherits_user_perms,omitempty"`
+	UserID            string      `json:"user_id,omitempty"`
+	Permission        []string    `json:"permissions"`
+	Roles             *TokenRoles `json:"roles,omitempty"`
+}
+
+// TokenRoles represents the role granted to a token in an organization.
+type TokenRoles struct {
+	Organization *Organization `json:"organization,omitempty"`
+	Role         string        `json:"role,omitempty"`
 }
 
 func (t *Token) String() string {
 	return fmt.Sprintf("id: %s, created: %s, expires: %s", t.ID, time.Time(t.CreationDate), time.Time(t.Expires))
 }
 
+// HasPermission reports whether the token grants permission, e.g.
+// "compute:read". Granted permissions ending with ":*" match every
+// permission of their prefix, and "*" matches every permission. The
+// permissions of the user are not known from the token, so tokens
+// inheriting them are reported to have none.
+func (t *Token) HasPermission(permission string) bool {
+	if t.InheritsUserPerms {
+		return false
+	}
+	for _, p := range t.Permission {
+		if p == permission || p == "*" ||
+			(strings.HasSuffix(p, ":*") && strings.HasPrefix(permission, p[:len(p)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenCreateOptions specifies the optional parameters to the
+// TokensService.CreateWithOptions method.
+type TokenCreateOptions struct {
+	// Expires requests a token expiring after 30 minutes, unless updated.
+	Expires bool
+	// Permissions restricts the token to the given permissions, e.g.
+	// "compute:read", instead of inheriting the permissions of the user.
+	Permissions []string
+	// Organization is the ID of the organization the token is scoped to.
+	Organization string
+}
+
 // tokenRequest represents a request to create a token.
 type tokenRequest struct {
 	*Credentials
-	Expires bool `json:"expires"`
+	Expires           bool     `json:"expires"`
+	InheritsUserPerms *bool    `json:"inherits_user_perms,omitempty"`
+	Permissions       []string `json:"permissions,omitempty"`
+	Organization      string   `json:"organization,omitempty"`
+}
+
+// TokenListOptions specifies the optional parameters to the
+// TokensService.List method. Filters are sent as query parameters and also
+// applied to the returned tokens, in case the API ignores them.
+type TokenListOptions struct {
+	// UserID filters tokens of the given user.
+	UserID string `url:"user_id,omitempty"`
+
+	ListOptions
+}
+
+func (opt *TokenListOptions) filter(tokens []*Token) []*Token {
+	if opt == nil {
+		return tokens
+	}
+	filtered := tokens[:0]
+	for _, t := range tokens {
+		if matchString(t.UserID, opt.UserID) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
 }
 
 // tokenResponse represents a Scaleway token creation response.
@@ -43,13 +108,60 @@ type tokenListResponse struct {
 	Tokens []*Token `json:"tokens"`
 }
 
+// maxTwoFactorAttempts is the number of times Create prompts for a two-factor
+// authentication code.
+const maxTwoFactorAttempts = 3
+
 // Create authenticates a user against their username, password, and then
-// returns a new Token, which can be used until it expires.
-func (s *TokensService) Create(credentials *Credentials, expires bool) (*Token, *Response, error) {
-	tr := &tokenRequest{
-		Credentials: credentials,
-		Expires:     expires,
+// returns a new Token, which can be used until it expires. If the account
+// has two-factor authentication enabled and the code of credentials is
+// missing or invalid, the TwoFactorPrompt of credentials is called for a new
+// code and the token creation retried. A *TwoFactorError is returned if no
+// valid code could be obtained. If credentials is nil, the request is sent
+// without credentials and two-factor authentication is not handled.
+func (s *TokensService) Create(ctx context.Context, credentials *Credentials, expires bool) (*Token, *Response, error) {
+	return s.CreateWithOptions(ctx, credentials, &TokenCreateOptions{Expires: expires})
+}
+
+// CreateWithOptions creates a token as Create does, optionally restricted
+// to a set of permissions and scoped to an organization.
+func (s *TokensService) CreateWithOptions(ctx context.Context, credentials *Credentials, opt *TokenCreateOptions) (*Token, *Response, error) {
+	tr := &tokenRequest{}
+	if opt != nil {
+		tr.Expires = opt.Expires
+		tr.Permissions = opt.Permissions
+		tr.Organization = opt.Organization
+		if len(opt.Permissions) > 0 {
+			tr.InheritsUserPerms = Bool(false)
+		}
+	}
+
+	if credentials == nil {
+		return s.createToken(ctx, tr)
 	}
+
+	c := *credentials
+	tr.Credentials = &c
+	for attempt := 0; ; attempt++ {
+		token, resp, err := s.createToken(ctx, tr)
+		e, ok := errorResponse(err)
+		if !ok || !isTwoFactorError(e) {
+			return token, resp, err
+		}
+
+		tfe := &TwoFactorError{ErrorResponse: e, Invalid: c.TwoFactorToken != ""}
+		if c.TwoFactorPrompt == nil || attempt == maxTwoFactorAttempts {
+			return nil, nil, tfe
+		}
+		code, err := c.TwoFactorPrompt(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.TwoFactorToken = code
+	}
+}
+
+func (s *TokensService) createToken(ctx context.Context, tr *tokenRequest) (*Token, *Response, error) {
 	u := fmt.Sprintf("/tokens")
 	req, err := s.client.NewRequestAccount("POST", u, tr)
 	if err != nil {
@@ -57,7 +169,7 @@ func (s *TokensService) Create(credentials *Credentials, expires bool) (*Token,
 	}
 
 	token := new(tokenResponse)
-	resp, err := s.client.Do(req, token)
+	resp, err := s.client.Do(ctx, req, token)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,27 +177,62 @@ func (s *TokensService) Create(credentials *Credentials, expires bool) (*Token,
 }
 
 // List returns a list of all tokens associate to your account.
-func (s *TokensService) List() ([]*Token, *Response, error) {
-	return s.listTokens()
+func (s *TokensService) List(ctx context.Context, opt *TokenListOptions) ([]*Token, *Response, error) {
+	return s.listTokens(ctx, opt)
 }
 
-func (s *TokensService) listTokens() ([]*Token, *Response, error) {
-	u := fmt.Sprintf("/tokens")
+func (s *TokensService) listTokens(ctx context.Context, opt *TokenListOptions) ([]*Token, *Response, error) {
+	u, err := addOptions("/tokens", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestAccount("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	tokens := new(tokenListResponse)
-	resp, err := s.client.Do(req, tokens)
+	resp, err := s.client.Do(ctx, req, tokens)
 	if err != nil {
 		return nil, nil, err
 	}
-	return tokens.Tokens, resp, nil
+	return opt.filter(tokens.Tokens), resp, nil
+}
+
+// ListPages calls fn for every page of tokens, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *TokensService) ListPages(ctx context.Context, opt *TokenListOptions, fn func([]*Token) bool) error {
+	o := TokenListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		tokens, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(tokens), nil
+	})
+}
+
+// ListAll returns the tokens of every page, starting from the page set in opt.
+func (s *TokensService) ListAll(ctx context.Context, opt *TokenListOptions) ([]*Token, error) {
+	var all []*Token
+	err := s.ListPages(ctx, opt, func(tokens []*Token) bool {
+		all = append(all, tokens...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
 }
 
 // Get returns info for a specific token.
-func (s *TokensService) Get(id string) (*Token, *Response, error) {
+func (s *TokensService) Get(ctx context.Context, id string) (*Token, *Response, error) {
 	u := fmt.Sprintf("/tokens/%s", id)
 	req, err := s.client.NewRequestAccount("GET", u, nil)
 	if err != nil {
@@ -93,7 +240,7 @@ func (s *TokensService) Get(id string) (*Token, *Response, error) {
 	}
 
 	token := new(tokenResponse)
-	resp, err := s.client.Do(req, token)
+	resp, err := s.client.Do(ctx, req, token)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -101,7 +248,7 @@ func (s *TokensService) Get(id string) (*Token, *Response, error) {
 }
 
 // Update increases token expiration time of 30 minutes.
-func (s *TokensService) Update(id string) (*Token, *Response, error) {
+func (s *TokensService) Update(ctx context.Context, id string) (*Token, *Response, error) {
 	u := fmt.Sprintf("/tokens/%s", id)
 	req, err := s.client.NewRequestAccount("PATCH", u, struct{}{})
 	if err != nil {
@@ -109,7 +256,7 @@ func (s *TokensService) Update(id string) (*Token, *Response, error) {
 	}
 
 	token := new(tokenResponse)
-	resp, err := s.client.Do(req, token)
+	resp, err := s.client.Do(ctx, req, token)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -117,13 +264,13 @@ func (s *TokensService) Update(id string) (*Token, *Response, error) {
 }
 
 // Delete deletes a token.
-func (s *TokensService) Delete(id string) (*Response, error) {
+func (s *TokensService) Delete(ctx context.Context, id string) (*Response, error) {
 	u := fmt.Sprintf("/tokens/%s", id)
 	req, err := s.client.NewRequestAccount("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -38,7 +39,7 @@ func TestTokensService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	token, _, err := client.Tokens.Create(credentials, tokenExpires)
+	token, _, err := client.Tokens.Create(context.Background(), credentials, tokenExpires)
 	if err != nil {
 		t.Errorf("Tokens.Create returned error: %v", err)
 	}
@@ -51,6 +52,7 @@ func TestTokensService_Create(t *testing.T) {
 		InheritsUserPerms: true,
 		UserID:            "5bea0358-db40-429e-bd82-953016a7e2s7",
 		Permission:        []string{},
+		Roles:             &TokenRoles{},
 	}
 	if !reflect.DeepEqual(token, want) {
 		t.Errorf("Tokens.Create returned %+v\n, want %+v", token, want)
@@ -71,7 +73,7 @@ func TestTokensService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	tokens, _, err := client.Tokens.List()
+	tokens, _, err := client.Tokens.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Tokens.List returned error: %v", err)
 	}
@@ -84,6 +86,7 @@ func TestTokensService_List(t *testing.T) {
 			InheritsUserPerms: true,
 			UserID:            "5bea0358-db40-429e-bd82-953016a7e2s7",
 			Permission:        []string{},
+			Roles:             &TokenRoles{},
 		},
 	}
 	if !reflect.DeepEqual(tokens, want) {
@@ -108,6 +111,7 @@ func TestTokensService_Get(t *testing.T) {
 		InheritsUserPerms: true,
 		UserID:            "5bea0358-db40-429e-bd82-953016a7e2s7",
 		Permission:        []string{},
+		Roles:             &TokenRoles{},
 	}
 
 	mux.HandleFunc(fmt.Sprintf("/tokens/%s", want.ID), func(w http.ResponseWriter, r *http.Request) {
@@ -116,7 +120,7 @@ func TestTokensService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	token, _, err := client.Tokens.Get(want.ID)
+	token, _, err := client.Tokens.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Tokens.Get returned error: %v", err)
 	}
@@ -142,6 +146,7 @@ func TestTokensService_Update(t *testing.T) {
 		InheritsUserPerms: true,
 		UserID:            "5bea0358-db40-429e-bd82-953016a7e2s7",
 		Permission:        []string{},
+		Roles:             &TokenRoles{},
 	}
 
 	mux.HandleFunc(fmt.Sprintf("/tokens/%s", want.ID), func(w http.ResponseWriter, r *http.Request) {
@@ -150,7 +155,7 @@ func TestTokensService_Update(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	token, _, err := client.Tokens.Update(want.ID)
+	token, _, err := client.Tokens.Update(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Tokens.Update returned error: %v", err)
 	}
@@ -172,8 +177,199 @@ func TestTokensService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.Tokens.Delete(tokenID)
+	_, err := client.Tokens.Delete(context.Background(), tokenID)
 	if err != nil {
 		t.Errorf("Tokens.Delete returned error: %v", err)
 	}
 }
+
+func TestTokensService_Create_twoFactor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var codes []string
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		v := new(tokenRequest)
+		json.NewDecoder(r.Body).Decode(v)
+		codes = append(codes, v.TwoFactorToken)
+
+		w.Header().Add("Content-Type", contentType)
+		if v.TwoFactorToken != "123456" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"type": "2FA_error", "message": "Two-factor authentication token is invalid or missing"}`)
+			return
+		}
+		fmt.Fprint(w, `{"token": {"id": "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"}}`)
+	})
+
+	credentials := NewCredentials("foo@bar.com", "foobar")
+	_, _, err := client.Tokens.Create(context.Background(), credentials, true)
+	if e, ok := err.(*TwoFactorError); !ok || e.Invalid {
+		t.Fatalf("Tokens.Create returned %v, want a missing code *TwoFactorError", err)
+	}
+
+	prompts := []string{"000000", "123456"}
+	credentials.TwoFactorPrompt = func(ctx context.Context) (string, error) {
+		code := prompts[0]
+		prompts = prompts[1:]
+		return code, nil
+	}
+	codes = nil
+	token, _, err := client.Tokens.Create(context.Background(), credentials, true)
+	if err != nil {
+		t.Fatalf("Tokens.Create returned error: %v", err)
+	}
+	if got, want := token.ID, "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"; got != want {
+		t.Errorf("Tokens.Create returned %v, want %v", got, want)
+	}
+	if want := []string{"", "000000", "123456"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("Tokens.Create sent codes %q, want %q", codes, want)
+	}
+	if credentials.TwoFactorToken != "" {
+		t.Errorf("Tokens.Create modified the credentials")
+	}
+
+	credentials.TwoFactorPrompt = func(ctx context.Context) (string, error) { return "000000", nil }
+	_, _, err = client.Tokens.Create(context.Background(), credentials, true)
+	if e, ok := err.(*TwoFactorError); !ok || !e.Invalid || !IsTwoFactorRequired(err) {
+		t.Errorf("Tokens.Create returned %v, want an invalid code *TwoFactorError", err)
+	}
+}
+
+func TestTokensService_Create_unauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", contentType)
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"type": "invalid_auth", "message": "Authentication error"}`)
+	})
+
+	credentials := NewCredentials("foo@bar.com", "foobar")
+	credentials.TwoFactorPrompt = func(ctx context.Context) (string, error) {
+		t.Error("TwoFactorPrompt called for a non 2FA error")
+		return "", nil
+	}
+	_, _, err := client.Tokens.Create(context.Background(), credentials, true)
+	if !IsUnauthorized(err) || IsTwoFactorRequired(err) {
+		t.Errorf("Tokens.Create returned %v, want a 401 error", err)
+	}
+}
+
+func TestTokensService_Create_nilCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		want := map[string]interface{}{"expires": true}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"token": {"id": "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"}}`)
+	})
+
+	token, _, err := client.Tokens.Create(context.Background(), nil, true)
+	if err != nil {
+		t.Fatalf("Tokens.Create returned error: %v", err)
+	}
+	if want := "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"; token.ID != want {
+		t.Errorf("Tokens.Create returned token %v, want %v", token.ID, want)
+	}
+}
+
+func TestTokensService_CreateWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		want := map[string]interface{}{
+			"email":               "foo@bar.com",
+			"password":            "foobar",
+			"expires":             true,
+			"inherits_user_perms": false,
+			"permissions":         []interface{}{"compute:read"},
+			"organization":        "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+		}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"token": {"id": "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7", "permissions": ["compute:read"]}}`)
+	})
+
+	opt := &TokenCreateOptions{
+		Expires:      true,
+		Permissions:  []string{"compute:read"},
+		Organization: "000a115d-2852-4b0a-9ce8-47f1134ba95a",
+	}
+	token, _, err := client.Tokens.CreateWithOptions(context.Background(), NewCredentials("foo@bar.com", "foobar"), opt)
+	if err != nil {
+		t.Fatalf("Tokens.CreateWithOptions returned error: %v", err)
+	}
+	if want := []string{"compute:read"}; !reflect.DeepEqual(token.Permission, want) {
+		t.Errorf("Tokens.CreateWithOptions returned permissions %v, want %v", token.Permission, want)
+	}
+}
+
+func TestTokensService_List_filter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("user_id"), "5bea0358-db40-429e-bd82-953016a7e2s7"; got != want {
+			t.Errorf("user_id is %v, want %v", got, want)
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"tokens": [
+			{"id": "1", "user_id": "5bea0358-db40-429e-bd82-953016a7e2s7"},
+			{"id": "2", "user_id": "0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d"}
+		]}`)
+	})
+
+	opt := &TokenListOptions{UserID: "5bea0358-db40-429e-bd82-953016a7e2s7"}
+	tokens, _, err := client.Tokens.List(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Tokens.List returned error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != "1" {
+		t.Errorf("Tokens.List returned %+v, want the token of the user only", tokens)
+	}
+}
+
+func TestToken_HasPermission(t *testing.T) {
+	token := &Token{Permission: []string{"compute:read", "account:*"}}
+
+	tests := []struct {
+		permission string
+		want       bool
+	}{
+		{"compute:read", true},
+		{"compute:write", false},
+		{"account:read", true},
+		{"account:write", true},
+		{"accounting:read", false},
+	}
+	for _, tt := range tests {
+		if got := token.HasPermission(tt.permission); got != tt.want {
+			t.Errorf("HasPermission(%q) returned %v, want %v", tt.permission, got, tt.want)
+		}
+	}
+
+	if !(&Token{Permission: []string{"*"}}).HasPermission("compute:write") {
+		t.Error("HasPermission returned false for a \"*\" token")
+	}
+	if (&Token{InheritsUserPerms: true}).HasPermission("compute:write") {
+		t.Error("HasPermission returned true for a token inheriting user permissions")
+	}
+}
@@ -0,0 +1,142 @@
+package scaleway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultRefreshBefore is the default delay before the expiration of a token
+// at which a TokenSource refreshes it.
+const defaultRefreshBefore = 5 * time.Minute
+
+// A TokenSource manages the lifecycle of an expiring token created from
+// Credentials: the token is created on first use, refreshed with
+// TokensService.Update shortly before it expires, and recreated when the API
+// rejects it. It is safe for concurrent use.
+//
+// Set it as the Authenticator of a Client to authenticate its requests:
+//
+//	client := scaleway.NewClient(nil)
+//	ts := scaleway.NewTokenSource(client, credentials)
+//	defer ts.Close(ctx)
+//	client.Authenticator = ts
+type TokenSource struct {
+	// Credentials used to create tokens.
+	Credentials *Credentials
+	// RefreshBefore is how long before its expiration a token is refreshed.
+	// Defaults to 5 minutes.
+	RefreshBefore time.Duration
+	// RevokeOnClose makes Close delete the current token.
+	RevokeOnClose bool
+
+	client *Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewTokenSource returns a TokenSource creating tokens from credentials
+// through the account API of c.
+func NewTokenSource(c *Client, credentials *Credentials) *TokenSource {
+	return &TokenSource{
+		Credentials: credentials,
+		client:      c,
+		now:         time.Now,
+	}
+}
+
+// Token returns a valid token, creating or refreshing it as needed.
+func (ts *TokenSource) Token(ctx context.Context) (*Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == nil {
+		return ts.create(ctx)
+	}
+	if !ts.expiresWithin(ts.refreshBefore()) {
+		return ts.token, nil
+	}
+
+	token, _, err := ts.tokens(ts.token.ID).Update(ctx, ts.token.ID)
+	switch {
+	case err == nil:
+		ts.token = token
+		return token, nil
+	case IsUnauthorized(err) || IsNotFound(err) || ts.expiresWithin(0):
+		return ts.create(ctx)
+	}
+	// The token is still valid, try to refresh it again on next use.
+	return ts.token, nil
+}
+
+// Close releases the token source, deleting the current token if
+// RevokeOnClose is set.
+func (ts *TokenSource) Close(ctx context.Context) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token := ts.token
+	ts.token = nil
+	if !ts.RevokeOnClose || token == nil {
+		return nil
+	}
+	_, err := ts.tokens(token.ID).Delete(ctx, token.ID)
+	return err
+}
+
+// Authenticate sets the X-Auth-Token header of req to a valid token.
+func (ts *TokenSource) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := ts.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerAuthToken, token.ID)
+	return nil
+}
+
+// Invalidate drops the token used by req if it is still the current one, so
+// that the next call to Token creates a new one.
+func (ts *TokenSource) Invalidate(req *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != nil && ts.token.ID == req.Header.Get(headerAuthToken) {
+		ts.token = nil
+	}
+}
+
+// create creates a new expiring token. ts.mu must be held.
+func (ts *TokenSource) create(ctx context.Context) (*Token, error) {
+	token, _, err := ts.tokens("").Create(ctx, ts.Credentials, true)
+	if err != nil {
+		return nil, err
+	}
+	ts.token = token
+	return token, nil
+}
+
+// expiresWithin reports whether the current token expires within d. Tokens
+// without expiration date never expire. ts.mu must be held.
+func (ts *TokenSource) expiresWithin(d time.Duration) bool {
+	expires := time.Time(ts.token.Expires)
+	return !expires.IsZero() && !ts.now().Add(d).Before(expires)
+}
+
+func (ts *TokenSource) refreshBefore() time.Duration {
+	if ts.RefreshBefore > 0 {
+		return ts.RefreshBefore
+	}
+	return defaultRefreshBefore
+}
+
+// tokens returns a TokensService authenticated with the token id, and
+// bypassing the Authenticator of the client.
+func (ts *TokenSource) tokens(id string) *TokensService {
+	c := *ts.client
+	c.Authenticator = nil
+	c.AuthToken = id
+	c.initServices()
+	return c.Tokens
+}
@@ -0,0 +1,169 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenJSON returns a token creation response for id expiring at expires.
+func tokenJSON(id string, expires time.Time) string {
+	return fmt.Sprintf(`{"token": {"id": %q, "expires": %q}}`, id, expires.Format("2006-01-02T15:04:05.000000-07:00"))
+}
+
+func TestTokenSource_Token(t *testing.T) {
+	setup()
+	defer teardown()
+
+	start := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	var created, updated int32
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		atomic.AddInt32(&created, 1)
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, tokenJSON("token-1", start.Add(30*time.Minute)))
+	})
+	mux.HandleFunc("/tokens/token-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		if got, want := r.Header.Get("X-Auth-Token"), "token-1"; got != want {
+			t.Errorf("X-Auth-Token is %v, want %v", got, want)
+		}
+		atomic.AddInt32(&updated, 1)
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, tokenJSON("token-1", start.Add(60*time.Minute)))
+	})
+
+	ts := NewTokenSource(client, NewCredentials("foo@bar.com", "foobar"))
+	ts.now = func() time.Time { return now }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ts.Token(context.Background()); err != nil {
+				t.Errorf("Token returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if created != 1 || updated != 0 {
+		t.Errorf("Token created %d and updated %d tokens, want 1 and 0", created, updated)
+	}
+
+	now = now.Add(26 * time.Minute)
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if created != 1 || updated != 1 {
+		t.Errorf("Token created %d and updated %d tokens, want 1 and 1", created, updated)
+	}
+	if got, want := time.Time(token.Expires), start.Add(60*time.Minute); !got.Equal(want) {
+		t.Errorf("Token expires at %v, want %v", got, want)
+	}
+}
+
+func TestTokenSource_revokedToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	start := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	var created int32
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&created, 1)
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, tokenJSON(fmt.Sprintf("token-%d", n), start.Add(30*time.Minute)))
+	})
+	mux.HandleFunc("/tokens/token-1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ts := NewTokenSource(client, NewCredentials("foo@bar.com", "foobar"))
+	ts.now = func() time.Time { return now }
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+
+	now = now.Add(29 * time.Minute)
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got, want := token.ID, "token-2"; got != want {
+		t.Errorf("Token returned %v, want %v", got, want)
+	}
+}
+
+func TestTokenSource_Authenticate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var created int32
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Auth-Token"); got != "" {
+			t.Errorf("Tokens.Create sent X-Auth-Token %v", got)
+		}
+		n := atomic.AddInt32(&created, 1)
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, tokenJSON(fmt.Sprintf("token-%d", n), time.Now().Add(30*time.Minute)))
+	})
+	mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		// token-1 has been revoked server side.
+		if r.Header.Get("X-Auth-Token") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, `{"servers": []}`)
+	})
+
+	client.Authenticator = NewTokenSource(client, NewCredentials("foo@bar.com", "foobar"))
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+	if created != 2 {
+		t.Errorf("TokenSource created %d tokens, want 2", created)
+	}
+}
+
+func TestTokenSource_Close(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", contentType)
+		fmt.Fprint(w, tokenJSON("token-1", time.Now().Add(30*time.Minute)))
+	})
+	deleted := false
+	mux.HandleFunc("/tokens/token-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := NewTokenSource(client, NewCredentials("foo@bar.com", "foobar"))
+	ts.RevokeOnClose = true
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+
+	if err := ts.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !deleted {
+		t.Error("Close didn't revoke the token")
+	}
+}
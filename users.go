@@ -1,6 +1,10 @@
 package scaleway
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
 
 // UsersService handles communication with the account API.
 type UsersService struct {
@@ -9,12 +13,20 @@ type UsersService struct {
 
 // User represents a Scaleway user.
 type User struct {
-	Email      string   `json:"email,omitempty"`
-	Firstname  string   `json:"firstname,omitempty"`
-	Lastname   string   `json:"lastname,omitempty"`
-	Fullname   string   `json:"fullname,omitempty"`
-	ID         string   `json:"id,omitempty"`
-	SSHPubKeys []string `json:"ssh_public_keys,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	Firstname  string    `json:"firstname,omitempty"`
+	Lastname   string    `json:"lastname,omitempty"`
+	Fullname   string    `json:"fullname,omitempty"`
+	ID         string    `json:"id,omitempty"`
+	SSHPubKeys []*SSHKey `json:"ssh_public_keys,omitempty"`
+}
+
+// UserRequest represents a request to update a user. Only the non-nil
+// fields are changed.
+type UserRequest struct {
+	Firstname  *string    `json:"firstname,omitempty"`
+	Lastname   *string    `json:"lastname,omitempty"`
+	SSHPubKeys *[]*SSHKey `json:"ssh_public_keys,omitempty"`
 }
 
 // userResponse represents a Scaleway token creation response.
@@ -23,7 +35,7 @@ type userResponse struct {
 }
 
 // Get returns info for a specific user.
-func (s *UsersService) Get(id string) (*User, *Response, error) {
+func (s *UsersService) Get(ctx context.Context, id string) (*User, *Response, error) {
 	u := fmt.Sprintf("/users/%s", id)
 	req, err := s.client.NewRequestAccount("GET", u, nil)
 	if err != nil {
@@ -31,9 +43,102 @@ func (s *UsersService) Get(id string) (*User, *Response, error) {
 	}
 
 	user := new(userResponse)
-	resp, err := s.client.Do(req, user)
+	resp, err := s.client.Do(ctx, req, user)
 	if err != nil {
 		return nil, nil, err
 	}
 	return user.User, resp, nil
 }
+
+// Update updates the details about a user.
+func (s *UsersService) Update(ctx context.Context, id string, ur *UserRequest) (*User, *Response, error) {
+	u := fmt.Sprintf("/users/%s", id)
+	req, err := s.client.NewRequestAccount("PATCH", u, ur)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(userResponse)
+	resp, err := s.client.Do(ctx, req, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user.User, resp, nil
+}
+
+// ListSSHKeys returns the parsed SSH public keys of a user.
+func (s *UsersService) ListSSHKeys(ctx context.Context, id string) ([]*SSHKey, *Response, error) {
+	user, resp, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]*SSHKey, 0, len(user.SSHPubKeys))
+	for _, k := range user.SSHPubKeys {
+		key, err := ParseSSHKey(k.Key)
+		if err != nil {
+			// Keep keys the API accepted even if they can't be parsed.
+			key = k
+		}
+		keys = append(keys, key)
+	}
+	return keys, resp, nil
+}
+
+// AddSSHKey adds the SSH public key to a user. The key is validated before
+// being sent; adding a key the user already has is a no-op.
+func (s *UsersService) AddSSHKey(ctx context.Context, id string, key string) (*User, *Response, error) {
+	added, addedBlob, err := parseSSHKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys, _, err := s.ListSSHKeys(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, k := range keys {
+		// Keys are compared by their data, as the fingerprints of keys
+		// that couldn't be parsed are the ones sent by the API.
+		if _, blob, err := parseSSHKey(k.Key); err == nil && bytes.Equal(blob, addedBlob) {
+			return s.Get(ctx, id)
+		}
+	}
+
+	return s.updateSSHKeys(ctx, id, append(keys, added))
+}
+
+// RemoveSSHKey removes the SSH public key with the given MD5 fingerprint
+// from a user. Keys that can't be parsed never match, as their fingerprint
+// can't be computed.
+func (s *UsersService) RemoveSSHKey(ctx context.Context, id string, fingerprint string) (*User, *Response, error) {
+	if fingerprint == "" {
+		return nil, nil, fmt.Errorf("empty SSH key fingerprint")
+	}
+
+	keys, _, err := s.ListSSHKeys(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kept := make([]*SSHKey, 0, len(keys))
+	for _, k := range keys {
+		if _, blob, err := parseSSHKey(k.Key); err != nil || sshFingerprint(blob) != fingerprint {
+			kept = append(kept, k)
+		}
+	}
+	if len(kept) == len(keys) {
+		return nil, nil, fmt.Errorf("user %s has no SSH key with fingerprint %q", id, fingerprint)
+	}
+
+	return s.updateSSHKeys(ctx, id, kept)
+}
+
+// updateSSHKeys replaces the SSH public keys of a user.
+func (s *UsersService) updateSSHKeys(ctx context.Context, id string, keys []*SSHKey) (*User, *Response, error) {
+	sent := make([]*SSHKey, len(keys))
+	for i, k := range keys {
+		sent[i] = &SSHKey{Key: k.Key}
+	}
+	return s.Update(ctx, id, &UserRequest{SSHPubKeys: &sent})
+}
@@ -1,6 +1,8 @@
 package scaleway
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -31,7 +33,7 @@ func TestUserService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	user, _, err := client.Users.Get(want.ID)
+	user, _, err := client.Users.Get(context.Background(), want.ID)
 	if err != nil {
 		t.Errorf("Users.Get returned error: %v", err)
 	}
@@ -40,3 +42,150 @@ func TestUserService_Get(t *testing.T) {
 		t.Errorf("Users.Get returned %#v\n, want %#v", user, want)
 	}
 }
+
+func TestUserService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.AuthToken = "654c95b0-2cf5-41a3-b3cc-733ffba4b4b7"
+
+	userID := "5bea0358-db40-429e-bd82-953016a7e2s7"
+
+	mux.HandleFunc(fmt.Sprintf("/users/%s", userID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		v := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+		if want := map[string]interface{}{"firstname": "Jon"}; !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body = %+v, want %+v", v, want)
+		}
+		fmt.Fprintf(w, `{"user": {"id": %q, "firstname": "Jon"}}`, userID)
+	})
+
+	user, _, err := client.Users.Update(context.Background(), userID, &UserRequest{Firstname: String("Jon")})
+	if err != nil {
+		t.Errorf("Users.Update returned error: %v", err)
+	}
+	if want := (&User{ID: userID, Firstname: "Jon"}); !reflect.DeepEqual(user, want) {
+		t.Errorf("Users.Update returned %+v, want %+v", user, want)
+	}
+}
+
+// testSSHKeysHandler serves a user whose SSH keys can be replaced by PATCH
+// requests.
+func testSSHKeysHandler(t *testing.T, userID string, keys []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			v := new(UserRequest)
+			json.NewDecoder(r.Body).Decode(v)
+			keys = keys[:0]
+			for _, k := range *v.SSHPubKeys {
+				keys = append(keys, k.Key)
+			}
+		} else {
+			testMethod(t, r, "GET")
+		}
+
+		user := &User{ID: userID, SSHPubKeys: []*SSHKey{}}
+		for _, k := range keys {
+			user.SSHPubKeys = append(user.SSHPubKeys, &SSHKey{Key: k})
+		}
+		json.NewEncoder(w).Encode(&userResponse{User: user})
+	}
+}
+
+func TestUserService_ListSSHKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	userID := "5bea0358-db40-429e-bd82-953016a7e2s7"
+	mux.HandleFunc(fmt.Sprintf("/users/%s", userID), testSSHKeysHandler(t, userID, []string{testSSHKeyEd25519}))
+
+	keys, _, err := client.Users.ListSSHKeys(context.Background(), userID)
+	if err != nil {
+		t.Errorf("Users.ListSSHKeys returned error: %v", err)
+	}
+
+	want := []*SSHKey{{
+		Key:         testSSHKeyEd25519,
+		Fingerprint: testSSHKeyEd25519Hash,
+		Type:        "ssh-ed25519",
+		Comment:     "jsnow@got.com",
+	}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Users.ListSSHKeys returned %+v, want %+v", keys, want)
+	}
+}
+
+func TestUserService_AddSSHKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	userID := "5bea0358-db40-429e-bd82-953016a7e2s7"
+	mux.HandleFunc(fmt.Sprintf("/users/%s", userID), testSSHKeysHandler(t, userID, []string{testSSHKeyEd25519}))
+
+	user, _, err := client.Users.AddSSHKey(context.Background(), userID, testSSHKeyRSA)
+	if err != nil {
+		t.Errorf("Users.AddSSHKey returned error: %v", err)
+	}
+	want := []*SSHKey{{Key: testSSHKeyEd25519}, {Key: testSSHKeyRSA}}
+	if !reflect.DeepEqual(user.SSHPubKeys, want) {
+		t.Errorf("Users.AddSSHKey returned keys %+v, want %+v", user.SSHPubKeys, want)
+	}
+
+	// adding a known key is a no-op
+	user, _, err = client.Users.AddSSHKey(context.Background(), userID, testSSHKeyRSA)
+	if err != nil {
+		t.Errorf("Users.AddSSHKey returned error: %v", err)
+	}
+	if !reflect.DeepEqual(user.SSHPubKeys, want) {
+		t.Errorf("Users.AddSSHKey returned keys %+v, want %+v", user.SSHPubKeys, want)
+	}
+
+	if _, _, err := client.Users.AddSSHKey(context.Background(), userID, "ssh-rsa garbage"); err == nil {
+		t.Error("Users.AddSSHKey accepted a malformed key")
+	}
+}
+
+func TestUserService_RemoveSSHKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	userID := "5bea0358-db40-429e-bd82-953016a7e2s7"
+	mux.HandleFunc(fmt.Sprintf("/users/%s", userID), testSSHKeysHandler(t, userID, []string{testSSHKeyEd25519, testSSHKeyRSA}))
+
+	user, _, err := client.Users.RemoveSSHKey(context.Background(), userID, testSSHKeyEd25519Hash)
+	if err != nil {
+		t.Errorf("Users.RemoveSSHKey returned error: %v", err)
+	}
+	if want := []*SSHKey{{Key: testSSHKeyRSA}}; !reflect.DeepEqual(user.SSHPubKeys, want) {
+		t.Errorf("Users.RemoveSSHKey returned keys %+v, want %+v", user.SSHPubKeys, want)
+	}
+
+	if _, _, err := client.Users.RemoveSSHKey(context.Background(), userID, testSSHKeyEd25519Hash); err == nil {
+		t.Error("Users.RemoveSSHKey returned no error for an unknown key")
+	}
+}
+
+func TestUserService_RemoveSSHKey_unparsed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	userID := "5bea0358-db40-429e-bd82-953016a7e2s7"
+	unparsed := "ssh-foo AAAA legacy"
+	mux.HandleFunc(fmt.Sprintf("/users/%s", userID), testSSHKeysHandler(t, userID, []string{unparsed, testSSHKeyRSA}))
+
+	// keys that can't be parsed have no fingerprint, but don't match an
+	// empty one
+	if _, _, err := client.Users.RemoveSSHKey(context.Background(), userID, ""); err == nil {
+		t.Error("Users.RemoveSSHKey returned no error for an empty fingerprint")
+	}
+
+	user, _, err := client.Users.RemoveSSHKey(context.Background(), userID, testSSHKeyRSAHash)
+	if err != nil {
+		t.Errorf("Users.RemoveSSHKey returned error: %v", err)
+	}
+	if want := []*SSHKey{{Key: unparsed}}; !reflect.DeepEqual(user.SSHPubKeys, want) {
+		t.Errorf("Users.RemoveSSHKey returned keys %+v, want %+v", user.SSHPubKeys, want)
+	}
+}
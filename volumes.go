@@ -1,6 +1,11 @@
 package scaleway
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // VolumesService handles communication with the volumes related
 // methods of the Scaleway API.
@@ -17,8 +22,11 @@ type Volume struct {
 	ExportURI    string `json:"export_uri,omitempty"`
 	Organization string `json:"organization,omitempty"`
 	//Server       string         `json:"server,omitempty"`
-	Size uint64 `json:"size,omitempty"`
-	Type string `json:"volume_type,omitempty"`
+	Size  uint64 `json:"size,omitempty"`
+	State string `json:"state,omitempty"`
+	Type  string `json:"volume_type,omitempty"`
+	// Region the volume lives in. Only set by ListAllRegions.
+	Region Region `json:"-"`
 }
 
 // VolumeRequest represents a request to create a volume.
@@ -29,6 +37,20 @@ type VolumeRequest struct {
 	Size         int    `json:"size"`
 }
 
+// VolumeListOptions specifies the optional parameters to the
+// VolumesService.List method. Filters are sent as query parameters and
+// also applied to the returned volumes, in case the API ignores them.
+type VolumeListOptions struct {
+	// Name filters volumes whose name contains the given string.
+	Name string `url:"name,omitempty"`
+	// Organization filters volumes owned by the given organization.
+	Organization string `url:"organization,omitempty"`
+	// Type filters volumes of the given type, e.g. "l_ssd".
+	Type string `url:"volume_type,omitempty"`
+
+	ListOptions
+}
+
 // volumeResponse represents a Scaleway volume creation response.
 type volumeResponse struct {
 	Volume *Volume `json:"volume"`
@@ -40,7 +62,7 @@ type volumeListResponse struct {
 }
 
 // Create volume corresponding as data storage for your server.
-func (s *VolumesService) Create(vr *VolumeRequest) (*Volume, *Response, error) {
+func (s *VolumesService) Create(ctx context.Context, vr *VolumeRequest) (*Volume, *Response, error) {
 	u := fmt.Sprintf("/volumes")
 	req, err := s.client.NewRequestCompute("POST", u, vr)
 	if err != nil {
@@ -48,7 +70,7 @@ func (s *VolumesService) Create(vr *VolumeRequest) (*Volume, *Response, error) {
 	}
 
 	volume := new(volumeResponse)
-	resp, err := s.client.Do(req, volume)
+	resp, err := s.client.Do(ctx, req, volume)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -56,27 +78,95 @@ func (s *VolumesService) Create(vr *VolumeRequest) (*Volume, *Response, error) {
 }
 
 // List returns a list of all volumes associate to your account.
-func (s *VolumesService) List() ([]*Volume, *Response, error) {
-	return s.listVolumes()
+func (s *VolumesService) List(ctx context.Context, opt *VolumeListOptions) ([]*Volume, *Response, error) {
+	return s.listVolumes(ctx, opt)
 }
 
-func (s *VolumesService) listVolumes() ([]*Volume, *Response, error) {
-	u := fmt.Sprintf("/volumes")
+func (s *VolumesService) listVolumes(ctx context.Context, opt *VolumeListOptions) ([]*Volume, *Response, error) {
+	u, err := addOptions("/volumes", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	volumes := new(volumeListResponse)
-	resp, err := s.client.Do(req, volumes)
+	resp, err := s.client.Do(ctx, req, volumes)
 	if err != nil {
 		return nil, nil, err
 	}
-	return volumes.Volumes, resp, nil
+	return opt.filter(volumes.Volumes), resp, nil
+}
+
+// ListPages calls fn for every page of volumes, starting from the page set
+// in opt, until there are no more pages or fn returns false. Each page is
+// only fetched once the previous one has been handled.
+func (s *VolumesService) ListPages(ctx context.Context, opt *VolumeListOptions, fn func([]*Volume) bool) error {
+	o := VolumeListOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	return walkPages(&o.ListOptions, func(lo *ListOptions) (*Response, bool, error) {
+		o.ListOptions = *lo
+		volumes, resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, fn(volumes), nil
+	})
+}
+
+// ListAll returns the volumes of every page, starting from the page set in opt.
+func (s *VolumesService) ListAll(ctx context.Context, opt *VolumeListOptions) ([]*Volume, error) {
+	var all []*Volume
+	err := s.ListPages(ctx, opt, func(volumes []*Volume) bool {
+		all = append(all, volumes...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ListAllRegions returns the volumes of every page in every region of
+// regions, or of all the Scaleway regions if regions is empty. Regions are
+// queried concurrently and the volumes are tagged with their region.
+func (s *VolumesService) ListAllRegions(ctx context.Context, regions []Region, opt *VolumeListOptions) ([]*Volume, error) {
+	if len(regions) == 0 {
+		regions = Regions()
+	}
+	results := make(map[Region][]*Volume, len(regions))
+	var mu sync.Mutex
+	err := s.client.EachRegion(ctx, regions, func(ctx context.Context, c *Client) error {
+		volumes, err := c.Volumes.ListAll(ctx, opt)
+		if err != nil {
+			return err
+		}
+		for _, volume := range volumes {
+			volume.Region = c.Region
+		}
+		mu.Lock()
+		results[c.Region] = volumes
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Volume
+	for _, region := range regions {
+		all = append(all, results[region]...)
+	}
+	return all, nil
 }
 
 // Get returns info for a specific volume.
-func (s *VolumesService) Get(id string) (*Volume, *Response, error) {
+func (s *VolumesService) Get(ctx context.Context, id string) (*Volume, *Response, error) {
 	u := fmt.Sprintf("/volumes/%s", id)
 	req, err := s.client.NewRequestCompute("GET", u, nil)
 	if err != nil {
@@ -84,7 +174,7 @@ func (s *VolumesService) Get(id string) (*Volume, *Response, error) {
 	}
 
 	volume := new(volumeResponse)
-	resp, err := s.client.Do(req, volume)
+	resp, err := s.client.Do(ctx, req, volume)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,15 +182,32 @@ func (s *VolumesService) Get(id string) (*Volume, *Response, error) {
 }
 
 // Delete deletes a volume.
-func (s *VolumesService) Delete(id string) (*Response, error) {
+func (s *VolumesService) Delete(ctx context.Context, id string) (*Response, error) {
 	u := fmt.Sprintf("/volumes/%s", id)
 	req, err := s.client.NewRequestCompute("DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.Do(req, nil)
+	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
+
+// filter returns the volumes matching the filters of opt.
+func (opt *VolumeListOptions) filter(volumes []*Volume) []*Volume {
+	if opt == nil {
+		return volumes
+	}
+	filtered := volumes[:0]
+	for _, v := range volumes {
+		if !strings.Contains(v.Name, opt.Name) ||
+			!matchString(v.Organization, opt.Organization) ||
+			!matchString(v.Type, opt.Type) {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+	return filtered
+}
@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -37,7 +38,7 @@ func TestVolumesService_Create(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	volume, _, err := client.Volumes.Create(inBody)
+	volume, _, err := client.Volumes.Create(context.Background(), inBody)
 	if err != nil {
 		t.Errorf("Tokens.Create returned error: %v", err)
 	}
@@ -77,7 +78,7 @@ func TestVolumesService_Get(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	volume, _, err := client.Volumes.Get(string(want.ID))
+	volume, _, err := client.Volumes.Get(context.Background(), string(want.ID))
 	if err != nil {
 		t.Errorf("Volumes.Get returned error: %v", err)
 	}
@@ -100,7 +101,7 @@ func TestVolumesService_List(t *testing.T) {
 		fmt.Fprint(w, string(data))
 	})
 
-	volumes, _, err := client.Volumes.List()
+	volumes, _, err := client.Volumes.List(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Volumes.List returned error: %v", err)
 	}
@@ -140,7 +141,7 @@ func TestVolumesService_Delete(t *testing.T) {
 		w.Header().Add("Content-Type", contentType)
 	})
 
-	_, err := client.Volumes.Delete(volumeID)
+	_, err := client.Volumes.Delete(context.Background(), volumeID)
 	if err != nil {
 		t.Errorf("Volumes.Delete returned error: %v", err)
 	}
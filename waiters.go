@@ -0,0 +1,193 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultMaxInterval  = 30 * time.Second
+)
+
+// WaitOptions specifies the optional parameters to the WaitFor methods.
+type WaitOptions struct {
+	// Timeout bounds the time spent waiting. Zero means waiting until ctx
+	// is done.
+	Timeout time.Duration
+	// PollInterval is the delay between the first two polls. Defaults to
+	// 2 seconds.
+	PollInterval time.Duration
+	// Backoff multiplies the delay after every poll. Values lower than or
+	// equal to 1 poll at a fixed interval.
+	Backoff float64
+	// MaxInterval caps the delay between two polls. Defaults to 30 seconds.
+	MaxInterval time.Duration
+	// Progress, if set, is called after every poll.
+	Progress func(WaitStatus)
+}
+
+// WaitStatus reports the state of a resource observed by a poll.
+type WaitStatus struct {
+	// ID of the resource being waited for.
+	ID string
+	// State of the resource, or Status of a task.
+	State string
+	// Progress of a task, as reported by Task.Progress.
+	Progress string
+	// Attempt is the number of polls made so far, this one included.
+	Attempt int
+	// Elapsed is the time spent waiting so far.
+	Elapsed time.Duration
+}
+
+// StateError occurs when a resource being waited for reaches a state from
+// which the wanted state can't be reached anymore.
+type StateError struct {
+	ID    string
+	State string
+	Want  string
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("%s reached state %q while waiting for %q", e.ID, e.State, e.Want)
+}
+
+// settledServerStates are the states in which a server stays until an
+// action is executed.
+var settledServerStates = map[string]bool{
+	"running":          true,
+	"stopped":          true,
+	"stopped in place": true,
+}
+
+// WaitForServerState polls the server id until its state is state. It fails
+// early if the server, after a transition such as "starting", settles in
+// another state, e.g. "stopped" while waiting for "running". A server that
+// has not started a transition yet is polled until the timeout, as the
+// action it is waiting for may still be pending.
+func (c *Client) WaitForServerState(ctx context.Context, id, state string, opt *WaitOptions) (*Server, error) {
+	var server *Server
+	transitioned := false
+	err := poll(ctx, opt, func(ctx context.Context) (WaitStatus, bool, error) {
+		s, _, err := c.Servers.Get(ctx, id)
+		if err != nil {
+			return WaitStatus{}, false, err
+		}
+		server = s
+		if !settledServerStates[s.State] {
+			transitioned = true
+		} else if transitioned && s.State != state {
+			return WaitStatus{}, false, &StateError{ID: id, State: s.State, Want: state}
+		}
+		return WaitStatus{ID: id, State: s.State}, s.State == state, nil
+	})
+	return server, err
+}
+
+// WaitForSnapshotState polls the snapshot id until its state is state.
+// It fails early if the snapshot ends up in the "error" state.
+func (c *Client) WaitForSnapshotState(ctx context.Context, id, state string, opt *WaitOptions) (*Snapshot, error) {
+	var snapshot *Snapshot
+	err := poll(ctx, opt, func(ctx context.Context) (WaitStatus, bool, error) {
+		s, _, err := c.Snapshots.Get(ctx, id)
+		if err != nil {
+			return WaitStatus{}, false, err
+		}
+		snapshot = s
+		if s.State == "error" && state != "error" {
+			return WaitStatus{}, false, &StateError{ID: id, State: s.State, Want: state}
+		}
+		return WaitStatus{ID: id, State: s.State}, s.State == state, nil
+	})
+	return snapshot, err
+}
+
+// WaitForVolumeState polls the volume id until its state is state.
+// It fails early if the volume ends up in the "error" state.
+func (c *Client) WaitForVolumeState(ctx context.Context, id, state string, opt *WaitOptions) (*Volume, error) {
+	var volume *Volume
+	err := poll(ctx, opt, func(ctx context.Context) (WaitStatus, bool, error) {
+		v, _, err := c.Volumes.Get(ctx, id)
+		if err != nil {
+			return WaitStatus{}, false, err
+		}
+		volume = v
+		if v.State == "error" && state != "error" {
+			return WaitStatus{}, false, &StateError{ID: id, State: v.State, Want: state}
+		}
+		return WaitStatus{ID: id, State: v.State}, v.State == state, nil
+	})
+	return volume, err
+}
+
+// WaitForTask polls the task id until it succeeds. It fails with a
+// *StateError if the task fails.
+func (c *Client) WaitForTask(ctx context.Context, id string, opt *WaitOptions) (*Task, error) {
+	var task *Task
+	err := poll(ctx, opt, func(ctx context.Context) (WaitStatus, bool, error) {
+		t, _, err := c.Tasks.Get(ctx, id)
+		if err != nil {
+			return WaitStatus{}, false, err
+		}
+		task = t
+		if t.Status == TaskFailure {
+			return WaitStatus{}, false, &StateError{ID: id, State: t.Status, Want: TaskSuccess}
+		}
+		return WaitStatus{ID: id, State: t.Status, Progress: t.Progress}, t.Status == TaskSuccess, nil
+	})
+	return task, err
+}
+
+// poll calls check until it reports done, fails, or the timeout of opt or
+// ctx expires.
+func poll(ctx context.Context, opt *WaitOptions, check func(ctx context.Context) (WaitStatus, bool, error)) error {
+	o := WaitOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultPollInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultMaxInterval
+	}
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := o.PollInterval
+	for attempt := 1; ; attempt++ {
+		status, done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if o.Progress != nil {
+			status.Attempt = attempt
+			status.Elapsed = time.Since(start)
+			o.Progress(status)
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if o.Backoff > 1 {
+			interval = time.Duration(float64(interval) * o.Backoff)
+			if interval > o.MaxInterval {
+				interval = o.MaxInterval
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testWaitOptions = &WaitOptions{PollInterval: time.Millisecond}
+
+func TestClient_WaitForServerState(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	states := []string{"stopped", "starting", "running"}
+	calls := 0
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"server": {"id": %q, "state": %q}}`, serverID, states[calls])
+		calls++
+	})
+
+	var seen []string
+	opt := *testWaitOptions
+	opt.Progress = func(s WaitStatus) {
+		seen = append(seen, s.State)
+	}
+
+	server, err := client.WaitForServerState(context.Background(), serverID, "running", &opt)
+	if err != nil {
+		t.Errorf("WaitForServerState returned error: %v", err)
+	}
+	if want := (&Server{ID: serverID, State: "running"}); !reflect.DeepEqual(server, want) {
+		t.Errorf("WaitForServerState returned %+v, want %+v", server, want)
+	}
+	if !reflect.DeepEqual(seen, states) {
+		t.Errorf("WaitForServerState reported progress %v, want %v", seen, states)
+	}
+}
+
+func TestClient_WaitForServerState_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"server": {"id": %q, "state": "starting"}}`, serverID)
+	})
+
+	opt := &WaitOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}
+	_, err := client.WaitForServerState(context.Background(), serverID, "running", opt)
+	if err != context.DeadlineExceeded {
+		t.Errorf("WaitForServerState returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_WaitForServerState_settled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serverID := "741db378-6b87-46d4-a8c5-4e46a09ab1f8"
+
+	states := []string{"stopped", "starting", "stopped", "running"}
+	calls := 0
+	mux.HandleFunc(fmt.Sprintf("/servers/%s", serverID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"server": {"id": %q, "state": %q}}`, serverID, states[calls])
+		calls++
+	})
+
+	_, err := client.WaitForServerState(context.Background(), serverID, "running", testWaitOptions)
+	if e, ok := err.(*StateError); !ok || e.State != "stopped" || e.Want != "running" {
+		t.Errorf("WaitForServerState returned error %v, want a *StateError", err)
+	}
+	if calls != 3 {
+		t.Errorf("WaitForServerState polled %d times, want 3", calls)
+	}
+}
+
+func TestClient_WaitForSnapshotState_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	snapshotID := "6f418e5f-b42d-4423-a0b5-349c74c454a4"
+
+	mux.HandleFunc(fmt.Sprintf("/snapshots/%s", snapshotID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"snapshot": {"id": %q, "state": "error"}}`, snapshotID)
+	})
+
+	_, err := client.WaitForSnapshotState(context.Background(), snapshotID, "available", testWaitOptions)
+	if _, ok := err.(*StateError); !ok {
+		t.Errorf("WaitForSnapshotState returned error %v, want a *StateError", err)
+	}
+}
+
+func TestClient_WaitForVolumeState(t *testing.T) {
+	setup()
+	defer teardown()
+
+	volumeID := "f929fe39-63f8-4be8-a80e-1e9c8ae22a76"
+
+	mux.HandleFunc(fmt.Sprintf("/volumes/%s", volumeID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"volume": {"id": %q, "state": "available"}}`, volumeID)
+	})
+
+	volume, err := client.WaitForVolumeState(context.Background(), volumeID, "available", testWaitOptions)
+	if err != nil {
+		t.Errorf("WaitForVolumeState returned error: %v", err)
+	}
+	if want := (&Volume{ID: volumeID, State: "available"}); !reflect.DeepEqual(volume, want) {
+		t.Errorf("WaitForVolumeState returned %+v, want %+v", volume, want)
+	}
+}
+
+func TestClient_WaitForTask(t *testing.T) {
+	setup()
+	defer teardown()
+
+	taskID := "a8a1775c-0dda-4f52-87b2-4e8101d68d6e"
+
+	progress := []string{"0", "50", "100"}
+	status := []string{"pending", "started", "success"}
+	calls := 0
+	mux.HandleFunc(fmt.Sprintf("/tasks/%s", taskID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"task": {"id": %q, "status": %q, "progress": %q}}`, taskID, status[calls], progress[calls])
+		calls++
+	})
+
+	var seen []string
+	opt := *testWaitOptions
+	opt.Backoff = 2
+	opt.Progress = func(s WaitStatus) {
+		seen = append(seen, s.Progress)
+	}
+
+	task, err := client.WaitForTask(context.Background(), taskID, &opt)
+	if err != nil {
+		t.Errorf("WaitForTask returned error: %v", err)
+	}
+	if task.Status != "success" {
+		t.Errorf("WaitForTask returned task with status %q, want success", task.Status)
+	}
+	if !reflect.DeepEqual(seen, progress) {
+		t.Errorf("WaitForTask reported progress %v, want %v", seen, progress)
+	}
+}
+
+func TestClient_WaitForTask_failure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	taskID := "a8a1775c-0dda-4f52-87b2-4e8101d68d6e"
+
+	mux.HandleFunc(fmt.Sprintf("/tasks/%s", taskID), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"task": {"id": %q, "status": "failure"}}`, taskID)
+	})
+
+	_, err := client.WaitForTask(context.Background(), taskID, testWaitOptions)
+	if _, ok := err.(*StateError); !ok {
+		t.Errorf("WaitForTask returned error %v, want a *StateError", err)
+	}
+}